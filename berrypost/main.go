@@ -1,16 +1,192 @@
 package main
 
 import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/realityone/berrypost/pkg/audit"
+	"github.com/realityone/berrypost/pkg/policy"
 	"github.com/realityone/berrypost/pkg/proxy"
 	"github.com/realityone/berrypost/pkg/server"
+	"github.com/realityone/berrypost/pkg/server/auth"
+	"github.com/realityone/berrypost/pkg/server/contrib/cors"
+	"github.com/realityone/berrypost/pkg/server/contrib/csrf"
 	"github.com/realityone/berrypost/pkg/server/management"
+	"github.com/realityone/berrypost/pkg/tracing"
+	"github.com/sirupsen/logrus"
 )
 
+func envOr(key, fallback string) string {
+	if v, ok := os.LookupEnv(key); ok {
+		return v
+	}
+	return fallback
+}
+
+func envList(key string) []string {
+	out := []string{}
+	for _, v := range strings.Split(os.Getenv(key), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func envBool(key string, fallback bool) bool {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		logrus.Fatalf("Invalid boolean %s=%q: %+v", key, v, err)
+	}
+	return b
+}
+
+func envDuration(key string, fallback time.Duration) time.Duration {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		logrus.Fatalf("Invalid duration %s=%q: %+v", key, v, err)
+	}
+	return d
+}
+
 func main() {
 	// debug server
+	otlpInsecure, _ := strconv.ParseBool(envOr("BERRYPOST_OTLP_INSECURE", "true"))
+	traceOpts := []tracing.Option{
+		tracing.SetExporter(os.Getenv("BERRYPOST_TRACE_EXPORTER")),
+		tracing.SetOTLPEndpoint(envOr("BERRYPOST_OTLP_ENDPOINT", "localhost:4318"), otlpInsecure),
+	}
+	if ratio, err := strconv.ParseFloat(os.Getenv("BERRYPOST_TRACE_SAMPLE_RATIO"), 64); err == nil {
+		traceOpts = append(traceOpts, tracing.SetSampleRatio(ratio))
+	}
+	shutdownTracing, err := tracing.Setup(context.Background(), traceOpts...)
+	if err != nil {
+		logrus.Fatalf("Failed to setup tracing: %+v", err)
+	}
+	defer shutdownTracing(context.Background())
+
 	components := []server.Component{}
-	components = append(components, management.New(), proxy.New())
+	proxyOpts := []proxy.ServerOpt{}
+	var readOnly *policy.ReadOnly
+	// the default deny list cuts off the internal backends berrypost is
+	// usually deployed next to, it is for instances open to untrusted users
+	denyDefaults, _ := strconv.ParseBool(os.Getenv("BERRYPOST_TARGET_DENY_DEFAULTS"))
+	targetGuard := &policy.TargetGuard{}
+	if denyDefaults {
+		targetGuard = policy.DefaultTargetGuard()
+	}
+	if path := os.Getenv("BERRYPOST_POLICY"); path != "" {
+		p, err := policy.Load(path)
+		if err != nil {
+			logrus.Fatalf("Failed to load policy: %+v", err)
+		}
+		proxyOpts = append(proxyOpts, proxy.SetPolicy(p))
+		readOnly = p.ReadOnly
+		if p.TargetGuard != nil {
+			if denyDefaults {
+				if err := p.TargetGuard.MergeDefaults(); err != nil {
+					logrus.Fatalf("Failed to load policy: %+v", err)
+				}
+			}
+			targetGuard = p.TargetGuard
+		}
+	}
+	if enabled, _ := strconv.ParseBool(os.Getenv("BERRYPOST_READ_ONLY")); enabled {
+		if readOnly == nil {
+			readOnly = &policy.ReadOnly{}
+		}
+		readOnly.Enabled = true
+	}
+
+	if disabled, _ := strconv.ParseBool(os.Getenv("BERRYPOST_DISABLE_USER_TARGETS")); disabled {
+		targetGuard.DisableUserDefinedTargets = true
+	}
+	proxyOpts = append(proxyOpts, proxy.SetTargetGuard(targetGuard))
+
+	mgmtOpts := []management.Option{management.SetReadOnly(readOnly), management.SetTraceURL(os.Getenv("BERRYPOST_TRACE_URL"))}
+	if path := os.Getenv("BERRYPOST_AUDIT_LOG"); path != "" && path != "-" {
+		sink, err := audit.NewFileSink(path)
+		if err != nil {
+			logrus.Fatalf("Failed to open audit log: %+v", err)
+		}
+		redactor, err := audit.NewRedactor(envList("BERRYPOST_AUDIT_KEEP_METADATA")...)
+		if err != nil {
+			logrus.Fatalf("Failed to setup audit log: %+v", err)
+		}
+		proxyOpts = append(proxyOpts, proxy.SetAuditSink(sink), proxy.SetAuditRedactor(redactor))
+		mgmtOpts = append(mgmtOpts, management.SetAuditLog(sink))
+	}
+
+	// server-wide reply rendering, requests override it with control headers
+	marshalOptions := proxy.DefaultMarshalOptions()
+	marshalOptions.EmitDefaults = envBool("BERRYPOST_EMIT_DEFAULTS", marshalOptions.EmitDefaults)
+	marshalOptions.OrigName = envBool("BERRYPOST_ORIG_NAME", marshalOptions.OrigName)
+	marshalOptions.EnumsAsInts = envBool("BERRYPOST_ENUMS_AS_INTS", marshalOptions.EnumsAsInts)
+	marshalOptions.Int64AsNumber = envBool("BERRYPOST_INT64_AS_NUMBER", marshalOptions.Int64AsNumber)
+	if v, ok := os.LookupEnv("BERRYPOST_INDENT"); ok {
+		indent, err := proxy.ParseIndent(v)
+		if err != nil {
+			logrus.Fatalf("Invalid indent BERRYPOST_INDENT=%q: %+v", v, err)
+		}
+		marshalOptions.Indent = indent
+	}
+	proxyOpts = append(proxyOpts, proxy.SetMarshalOptions(marshalOptions))
+
+	mgmt := management.New(mgmtOpts...)
+	proxyOpts = append(proxyOpts, proxy.SetProtoStore(management.NewProtoStore(mgmt.ProtoManager())), proxy.SetReadOnly(readOnly))
+	components = append(components, mgmt, proxy.New(proxyOpts...))
 
-	server := server.New(server.SetComponents(components))
-	server.Serve()
+	opts := []server.Option{server.SetComponents(components)}
+	authenticator, err := auth.FromEnv()
+	if err != nil {
+		logrus.Fatalf("Failed to setup authentication: %+v", err)
+	}
+	if authenticator != nil {
+		opts = append(opts, server.SetAuthenticator(authenticator))
+	}
+	origins := envList("BERRYPOST_CORS_ORIGINS")
+	if len(origins) > 0 {
+		corsConfig := cors.DefaultConfig(origins...)
+		corsConfig.AllowCredentials, _ = strconv.ParseBool(os.Getenv("BERRYPOST_CORS_CREDENTIALS"))
+		opts = append(opts, server.SetCORS(&corsConfig))
+	}
+	// CSRF protection defaults to on once authentication is configured
+	csrfEnabled, err := strconv.ParseBool(os.Getenv("BERRYPOST_CSRF"))
+	if err != nil {
+		csrfEnabled = authenticator != nil
+	}
+	if csrfEnabled {
+		opts = append(opts, server.SetCSRF(csrf.New(csrf.SetTrustedOrigins(origins...))))
+	} else {
+		opts = append(opts, server.SetCSRF(nil))
+	}
+	listen := server.DefaultListenConfig()
+	opts = append(opts,
+		server.SetListenAddr(envOr("BERRYPOST_ADDR", listen.Addr)),
+		server.SetTLSCertificate(os.Getenv("BERRYPOST_TLS_CERT"), os.Getenv("BERRYPOST_TLS_KEY")),
+		server.SetTimeouts(server.Timeouts{
+			ReadHeader: listen.Timeouts.ReadHeader,
+			Read:       envDuration("BERRYPOST_READ_TIMEOUT", listen.Timeouts.Read),
+			Write:      envDuration("BERRYPOST_WRITE_TIMEOUT", listen.Timeouts.Write),
+			Idle:       listen.Timeouts.Idle,
+			Shutdown:   envDuration("BERRYPOST_SHUTDOWN_TIMEOUT", listen.Timeouts.Shutdown),
+		}),
+	)
+	server := server.New(opts...)
+	if err := server.Serve(); err != nil {
+		logrus.Errorf("Server exited: %+v", err)
+		shutdownTracing(context.Background())
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,142 @@
+package audit
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Record is the audit record of one invocation.
+type Record struct {
+	Time          time.Time `json:"time"`
+	Subject       string    `json:"subject,omitempty"`
+	ClientIP      string    `json:"client_ip"`
+	Method        string    `json:"method"`
+	Target        string    `json:"target,omitempty"`
+	Resolver      string    `json:"resolver,omitempty"`
+	ProtoRevision string    `json:"proto_revision,omitempty"`
+	ProtoPath     string    `json:"proto_path,omitempty"`
+	// Metadata is the forwarded gRPC metadata, with values redacted.
+	Metadata      map[string][]string `json:"metadata,omitempty"`
+	RequestSize   int                 `json:"request_size"`
+	RequestSHA256 string              `json:"request_sha256,omitempty"`
+	HTTPStatus    int                 `json:"http_status"`
+	GRPCCode      string              `json:"grpc_code"`
+	Error         string              `json:"error,omitempty"`
+	LatencyMillis float64             `json:"latency_ms"`
+	TraceID       string              `json:"trace_id,omitempty"`
+}
+
+// Sink stores the audit records.
+type Sink interface {
+	Write(*Record) error
+	Close() error
+}
+
+// Querier is implemented by sinks which can be searched.
+type Querier interface {
+	Query(context.Context, *Query) ([]*Record, error)
+}
+
+// Query filters the records, empty fields match anything. Method accepts *
+// as a wildcard, e.g. *DeleteAccount.
+type Query struct {
+	Subject  string
+	Method   string
+	Target   string
+	GRPCCode string
+	Since    time.Time
+	Until    time.Time
+	// Limit defaults to 100.
+	Limit int
+}
+
+const DefaultQueryLimit = 100
+
+func (q *Query) limit() int {
+	if q.Limit <= 0 {
+		return DefaultQueryLimit
+	}
+	return q.Limit
+}
+
+func (q *Query) Match(r *Record) bool {
+	if q.Subject != "" && q.Subject != r.Subject {
+		return false
+	}
+	if q.Method != "" && !globMatch(q.Method, strings.TrimPrefix(r.Method, "/")) && !globMatch(q.Method, r.Method) {
+		return false
+	}
+	if q.Target != "" && !globMatch(q.Target, r.Target) {
+		return false
+	}
+	if q.GRPCCode != "" && !strings.EqualFold(q.GRPCCode, r.GRPCCode) {
+		return false
+	}
+	if !q.Since.IsZero() && r.Time.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && !r.Time.Before(q.Until) {
+		return false
+	}
+	return true
+}
+
+func globMatch(pattern, in string) bool {
+	parts := strings.Split(pattern, "*")
+	for i, p := range parts {
+		parts[i] = regexp.QuoteMeta(p)
+	}
+	matched, _ := regexp.MatchString("^"+strings.Join(parts, ".*")+"$", in)
+	return matched
+}
+
+const Redacted = "[redacted]"
+
+// Redactor decides which metadata values are written in clear, every other
+// value is replaced by Redacted.
+type Redactor struct {
+	keep []*regexp.Regexp
+}
+
+// NewRedactor keeps the values of the metadata keys matching any of the
+// regular expressions, e.g. ^x-request-id$.
+func NewRedactor(keep ...string) (*Redactor, error) {
+	r := &Redactor{}
+	for _, k := range keep {
+		re, err := regexp.Compile("(?i)" + k)
+		if err != nil {
+			return nil, errors.Wrapf(err, "redaction rule %q", k)
+		}
+		r.keep = append(r.keep, re)
+	}
+	return r, nil
+}
+
+func (r *Redactor) Redact(md map[string][]string) map[string][]string {
+	out := make(map[string][]string, len(md))
+	for k, vs := range md {
+		if r != nil && r.kept(k) {
+			out[k] = append([]string{}, vs...)
+			continue
+		}
+		redacted := make([]string, len(vs))
+		for i := range redacted {
+			redacted[i] = Redacted
+		}
+		out[k] = redacted
+	}
+	return out
+}
+
+func (r *Redactor) kept(key string) bool {
+	for _, re := range r.keep {
+		if re.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSinkRotateAndQuery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	sink, err := NewFileSink(path, SetMaxSize(600), SetMaxBackups(2))
+	require.NoError(t, err)
+	defer sink.Close()
+
+	start := time.Date(2026, 10, 13, 10, 0, 0, 0, time.UTC)
+	for i := 0; i < 12; i++ {
+		method := "/accounts.v1.AccountService/GetAccount"
+		if i%4 == 3 {
+			method = "/accounts.v1.AccountService/DeleteAccount"
+		}
+		require.NoError(t, sink.Write(&Record{
+			Time:     start.Add(time.Duration(i) * time.Hour),
+			Subject:  fmt.Sprintf("user-%d", i%2),
+			Method:   method,
+			Target:   "accounts.prod.internal:9000",
+			GRPCCode: "OK",
+		}))
+	}
+	_, err = os.Stat(path + ".2")
+	require.NoError(t, err)
+	_, err = os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err))
+
+	records, err := sink.Query(context.Background(), &Query{Method: "*DeleteAccount", Target: "*.prod.internal:*"})
+	require.NoError(t, err)
+	require.NotEmpty(t, records)
+	assert.Equal(t, start.Add(11*time.Hour), records[0].Time.UTC())
+	for i, r := range records {
+		assert.Equal(t, "/accounts.v1.AccountService/DeleteAccount", r.Method)
+		if i > 0 {
+			assert.True(t, r.Time.Before(records[i-1].Time))
+		}
+	}
+
+	records, err = sink.Query(context.Background(), &Query{
+		Subject: "user-1",
+		Since:   start.Add(8 * time.Hour),
+		Until:   start.Add(11 * time.Hour),
+		Limit:   1,
+	})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, start.Add(9*time.Hour), records[0].Time.UTC())
+}
+
+func TestFileSinkRotateFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	sink, err := NewFileSink(path, SetMaxSize(200), SetMaxBackups(1))
+	require.NoError(t, err)
+	defer sink.Close()
+
+	// a directory in the way of the backup fails the rotation
+	require.NoError(t, os.MkdirAll(filepath.Join(path+".1", "busy"), 0700))
+	record := &Record{Subject: "user-0", Method: "/accounts.v1.AccountService/GetAccount", GRPCCode: "OK"}
+	for err == nil {
+		err = sink.Write(record)
+	}
+	assert.NotNil(t, sink.file)
+
+	require.NoError(t, os.RemoveAll(path+".1"))
+	require.NoError(t, sink.Write(record))
+	_, err = os.Stat(path + ".1")
+	assert.NoError(t, err)
+
+	require.NoError(t, sink.Close())
+	assert.Error(t, sink.Write(record))
+}
+
+func TestFileSinkQueryWhileWriting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	sink, err := NewFileSink(path, SetMaxSize(1000), SetMaxBackups(3))
+	require.NoError(t, err)
+	defer sink.Close()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 500; i++ {
+			assert.NoError(t, sink.Write(&Record{Subject: fmt.Sprintf("user-%d", i), GRPCCode: "OK"}))
+		}
+	}()
+	for i := 0; i < 20; i++ {
+		records, err := sink.Query(context.Background(), &Query{Limit: 5})
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(records), 5)
+	}
+	<-done
+
+	records, err := sink.Query(context.Background(), &Query{Limit: 3})
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, []string{"user-499", "user-498", "user-497"}, []string{records[0].Subject, records[1].Subject, records[2].Subject})
+}
+
+func TestRedactor(t *testing.T) {
+	r, err := NewRedactor("^x-request-id$")
+	require.NoError(t, err)
+	md := map[string][]string{
+		"authorization": {"Bearer secret"},
+		"x-request-id":  {"req-1"},
+	}
+	assert.Equal(t, map[string][]string{
+		"authorization": {Redacted},
+		"x-request-id":  {"req-1"},
+	}, r.Redact(md))
+
+	var none *Redactor
+	assert.Equal(t, []string{Redacted}, none.Redact(md)["x-request-id"])
+
+	_, err = NewRedactor("(")
+	assert.Error(t, err)
+}
@@ -0,0 +1,256 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	DefaultMaxSize    = 64 << 20
+	DefaultMaxBackups = 7
+)
+
+type FileSinkOpt func(*FileSink)
+
+// SetMaxSize rotates the file once it grows over size bytes.
+func SetMaxSize(size int64) FileSinkOpt {
+	return func(s *FileSink) {
+		s.maxSize = size
+	}
+}
+
+// SetMaxBackups keeps n rotated files, path.1 being the newest.
+func SetMaxBackups(n int) FileSinkOpt {
+	return func(s *FileSink) {
+		s.maxBackups = n
+	}
+}
+
+// FileSink appends the records to a JSON lines file and rotates it by size.
+type FileSink struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	mu     sync.Mutex
+	file   *os.File
+	size   int64
+	closed bool
+}
+
+func NewFileSink(path string, opts ...FileSinkOpt) (*FileSink, error) {
+	s := &FileSink{
+		path:       path,
+		maxSize:    DefaultMaxSize,
+		maxBackups: DefaultMaxBackups,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *FileSink) open() error {
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return errors.Wrap(err, "open audit log")
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return errors.Wrap(err, "open audit log")
+	}
+	s.file, s.size = f, info.Size()
+	return nil
+}
+
+func (s *FileSink) backup(i int) string {
+	return fmt.Sprintf("%s.%d", s.path, i)
+}
+
+func (s *FileSink) shift() error {
+	if s.maxBackups <= 0 {
+		if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, "rotate audit log")
+		}
+		return nil
+	}
+	os.Remove(s.backup(s.maxBackups))
+	for i := s.maxBackups - 1; i >= 1; i-- {
+		if err := os.Rename(s.backup(i), s.backup(i+1)); err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, "rotate audit log")
+		}
+	}
+	return errors.Wrap(os.Rename(s.path, s.backup(1)), "rotate audit log")
+}
+
+// rotate leaves the file unset if it can not be opened again, the next write
+// retries opening it.
+func (s *FileSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		logrus.Warnf("Failed to close audit log %q: %+v", s.path, err)
+	}
+	s.file = nil
+	if err := s.shift(); err != nil {
+		// keep appending to the current file
+		if openErr := s.open(); openErr != nil {
+			logrus.Warnf("Failed to reopen audit log %q: %+v", s.path, openErr)
+		}
+		return err
+	}
+	return s.open()
+}
+
+func (s *FileSink) Write(r *Record) error {
+	line, err := json.Marshal(r)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return errors.New("audit log is closed")
+	}
+	if s.file == nil {
+		if err := s.open(); err != nil {
+			return err
+		}
+	}
+	if s.size > 0 && s.size+int64(len(line)) > s.maxSize {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := s.file.Write(line)
+	s.size += int64(n)
+	return errors.Wrap(err, "write audit log")
+}
+
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+// auditFile is a file of the log opened for reading, size being what was
+// written when opened.
+type auditFile struct {
+	path string
+	file *os.File
+	size int64
+}
+
+// openFiles opens the existing files, the newest first. The lock is only held
+// while opening, the opened files are read as they were, even once rotated.
+func (s *FileSink) openFiles() ([]*auditFile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	paths := []string{s.path}
+	for i := 1; i <= s.maxBackups; i++ {
+		paths = append(paths, s.backup(i))
+	}
+	out := []*auditFile{}
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err == nil {
+			var info os.FileInfo
+			if info, err = f.Stat(); err == nil {
+				out = append(out, &auditFile{path: path, file: f, size: info.Size()})
+				continue
+			}
+			f.Close()
+		}
+		for _, opened := range out {
+			opened.file.Close()
+		}
+		return nil, errors.Wrap(err, "read audit log")
+	}
+	return out, nil
+}
+
+// Query returns the matching records, the newest first.
+func (s *FileSink) Query(ctx context.Context, q *Query) ([]*Record, error) {
+	files, err := s.openFiles()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		for _, f := range files {
+			f.file.Close()
+		}
+	}()
+
+	out := []*Record{}
+	for _, f := range files {
+		if len(out) >= q.limit() {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		matched, err := readRecords(ctx, f, q, q.limit()-len(out))
+		if err != nil {
+			return nil, err
+		}
+		for i := len(matched) - 1; i >= 0; i-- {
+			out = append(out, matched[i])
+		}
+	}
+	return out, nil
+}
+
+// readRecords returns the last n matching records of the file, oldest first.
+func readRecords(ctx context.Context, f *auditFile, q *Query, n int) ([]*Record, error) {
+	// a ring of the last n records, next being the oldest once full
+	ring, next := make([]*Record, 0, n), 0
+	scanner := bufio.NewScanner(io.LimitReader(f.file, f.size))
+	scanner.Buffer(make([]byte, 64<<10), 16<<20)
+	for lines := 0; scanner.Scan(); lines++ {
+		if lines%1024 == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		r := &Record{}
+		if err := json.Unmarshal(scanner.Bytes(), r); err != nil {
+			logrus.Warnf("Skipping malformed audit record in %q: %+v", f.path, err)
+			continue
+		}
+		if !q.Match(r) {
+			continue
+		}
+		if len(ring) < n {
+			ring = append(ring, r)
+			continue
+		}
+		ring[next] = r
+		next = (next + 1) % n
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "read audit log")
+	}
+	return append(ring[next:], ring[:next]...), nil
+}
@@ -0,0 +1,124 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "berrypost"
+
+// Unknown labels what is not known to berrypost, so that clients can not
+// create series at will: services and methods missing from the protos, and
+// invocations failing before their target is resolved.
+const Unknown = "unknown"
+
+// Registry holds the berrypost collectors as well as the Go runtime and
+// process ones, it is served by Handler.
+var Registry = prometheus.NewRegistry()
+
+var (
+	// Invocations counts the invocations by service, method, the resolver
+	// which resolved the target and gRPC code.
+	Invocations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "invocations_total",
+		Help:      "Invocations by service, method, resolver and gRPC code.",
+	}, []string{"service", "method", "resolver", "code"})
+
+	// InvokeDuration is the end-to-end latency of the invoke API.
+	InvokeDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "invoke_duration_seconds",
+		Help:      "End-to-end latency of invocations, from the request to the marshaled reply.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"service", "method", "code"})
+
+	// DialDuration is the latency of dialing backends.
+	DialDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "dial_duration_seconds",
+		Help:      "Latency of dialing gRPC connections to backends.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"result"})
+
+	// RPCDuration is the latency of the gRPC calls to backends.
+	RPCDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "rpc_duration_seconds",
+		Help:      "Latency of the gRPC calls to backends.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"service", "method", "code"})
+
+	// ResolverResults counts the outcomes of each resolver, by its Name().
+	ResolverResults = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "resolver_results_total",
+		Help:      "Resolutions by resolver and result.",
+	}, []string{"resolver", "result"})
+
+	// ProtoLookupDuration is the latency of looking up descriptors in proto
+	// stores and proto managers.
+	ProtoLookupDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "proto_lookup_duration_seconds",
+		Help:      "Latency of proto store and proto manager lookups.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"source", "operation"})
+
+	// CacheRequests counts the hits and misses of the caches.
+	CacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_requests_total",
+		Help:      "Cache lookups by cache and result, hit or miss.",
+	}, []string{"cache", "result"})
+
+	// OpenConnections is the number of gRPC connections to backends.
+	OpenConnections = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "open_connections",
+		Help:      "gRPC connections to backends currently open.",
+	})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		Invocations,
+		InvokeDuration,
+		DialDuration,
+		RPCDuration,
+		ResolverResults,
+		ProtoLookupDuration,
+		CacheRequests,
+		OpenConnections,
+	)
+}
+
+// Handler serves the metrics of Registry in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// Since returns the seconds elapsed since start.
+func Since(start time.Time) float64 {
+	return time.Since(start).Seconds()
+}
+
+// ObserveLookup records the latency of a proto lookup started at start.
+func ObserveLookup(source, operation string, start time.Time) {
+	ProtoLookupDuration.WithLabelValues(source, operation).Observe(Since(start))
+}
+
+// CacheHit counts a hit of the cache if hit is true, a miss otherwise.
+func CacheHit(cache string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	CacheRequests.WithLabelValues(cache, result).Inc()
+}
@@ -0,0 +1,274 @@
+package policy
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type Effect string
+
+const (
+	Allow Effect = "allow"
+	Deny  Effect = "deny"
+
+	DefaultRule = "default"
+)
+
+// Rule matches an invocation if every condition set on it matches, an empty
+// condition matches anything. Patterns accept * as a wildcard.
+type Rule struct {
+	Name   string `json:"name"`
+	Effect Effect `json:"effect"`
+	// Users and Groups match the authenticated identity, an anonymous call
+	// only matches rules without them.
+	Users  []string `json:"users,omitempty"`
+	Groups []string `json:"groups,omitempty"`
+	// Methods are patterns of the fully qualified method, e.g.
+	// payments.*/Refund or payments.v1.PaymentService/*.
+	Methods []string `json:"methods,omitempty"`
+	// Targets are CIDRs or host patterns of the resolved target.
+	Targets   []string `json:"targets,omitempty"`
+	Revisions []string `json:"revisions,omitempty"`
+	// IdempotencyLevels are values of the idempotency_level method option:
+	// IDEMPOTENCY_UNKNOWN, NO_SIDE_EFFECTS or IDEMPOTENT.
+	IdempotencyLevels []string `json:"idempotency_levels,omitempty"`
+
+	users, groups     []*regexp.Regexp
+	methods           []*regexp.Regexp
+	targets           []targetMatcher
+	revisions         []*regexp.Regexp
+	idempotencyLevels []*regexp.Regexp
+}
+
+// Policy is an ordered list of rules, the first matching rule decides.
+type Policy struct {
+	// DryRun only reports the denials instead of enforcing them.
+	DryRun bool `json:"dry_run"`
+	// Default is the effect if no rule matches, allow if empty.
+	Default Effect  `json:"default,omitempty"`
+	Rules   []*Rule `json:"rules"`
+	// ReadOnly is the read-only mode of the server, if any.
+	ReadOnly *ReadOnly `json:"read_only,omitempty"`
+	// TargetGuard restricts the targets given by users, if any.
+	TargetGuard *TargetGuard `json:"target_guard,omitempty"`
+}
+
+// Request is the invocation being authorized.
+type Request struct {
+	Subject          string
+	Groups           []string
+	Service          string
+	Method           string
+	Target           string
+	Revision         string
+	IdempotencyLevel string
+}
+
+type Decision struct {
+	Effect Effect
+	// Rule is the name of the matched rule, DefaultRule if none matched.
+	Rule   string
+	DryRun bool
+}
+
+// Allowed reports whether the invocation may proceed, denials in dry run are
+// allowed.
+func (d *Decision) Allowed() bool {
+	return d.Effect != Deny || d.DryRun
+}
+
+func (d *Decision) String() string {
+	return string(d.Effect) + " by rule " + d.Rule
+}
+
+// Load reads a policy from a JSON file.
+func Load(path string) (*Policy, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	p := &Policy{}
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, errors.Wrapf(err, "parse policy %q", path)
+	}
+	if err := p.Compile(); err != nil {
+		return nil, errors.Wrapf(err, "policy %q", path)
+	}
+	return p, nil
+}
+
+// Compile checks the rules and prepares their patterns, it is needed for
+// policies built in code.
+func (p *Policy) Compile() error {
+	if err := checkEffect(&p.Default, Allow); err != nil {
+		return errors.Wrap(err, "default")
+	}
+	if p.TargetGuard != nil {
+		if err := p.TargetGuard.Compile(); err != nil {
+			return errors.Wrap(err, "target guard")
+		}
+	}
+	if p.ReadOnly != nil {
+		p.ReadOnly.Compile()
+	}
+	for i, r := range p.Rules {
+		if r.Name == "" {
+			r.Name = "rule-" + strconv.Itoa(i)
+		}
+		if err := checkEffect(&r.Effect, Deny); err != nil {
+			return errors.Wrapf(err, "rule %q", r.Name)
+		}
+		r.users = globRegexps(r.Users)
+		r.groups = globRegexps(r.Groups)
+		r.methods = globRegexps(r.Methods)
+		r.revisions = globRegexps(r.Revisions)
+		r.idempotencyLevels = globRegexps(r.IdempotencyLevels)
+		r.targets = r.targets[:0]
+		for _, t := range r.Targets {
+			r.targets = append(r.targets, newTargetMatcher(t))
+		}
+	}
+	return nil
+}
+
+func checkEffect(e *Effect, fallback Effect) error {
+	switch *e {
+	case "":
+		*e = fallback
+	case Allow, Deny:
+	default:
+		return errors.Errorf("unknown effect %q", *e)
+	}
+	return nil
+}
+
+// Evaluate returns the decision of the first matching rule.
+func (p *Policy) Evaluate(req *Request) *Decision {
+	for _, r := range p.Rules {
+		if r.Match(req) {
+			return &Decision{Effect: r.Effect, Rule: r.Name, DryRun: p.DryRun}
+		}
+	}
+	effect := p.Default
+	if effect == "" {
+		effect = Allow
+	}
+	return &Decision{Effect: effect, Rule: DefaultRule, DryRun: p.DryRun}
+}
+
+func (r *Rule) Match(req *Request) bool {
+	if len(r.Users) > 0 && !matchAny(r.users, req.Subject) {
+		return false
+	}
+	if len(r.Groups) > 0 {
+		found := false
+		for _, g := range req.Groups {
+			if matchAny(r.groups, g) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(r.methods) > 0 {
+		fullName := req.Service + "/" + req.Method
+		found := false
+		for _, m := range r.methods {
+			if m.MatchString(fullName) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(r.targets) > 0 {
+		host := targetHost(req.Target)
+		found := false
+		for _, t := range r.targets {
+			if t.match(host) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(r.Revisions) > 0 && !matchAny(r.revisions, req.Revision) {
+		return false
+	}
+	if len(r.IdempotencyLevels) > 0 && !matchAny(r.idempotencyLevels, req.IdempotencyLevel) {
+		return false
+	}
+	return true
+}
+
+func matchAny(patterns []*regexp.Regexp, in string) bool {
+	if in == "" {
+		return false
+	}
+	for _, p := range patterns {
+		if p.MatchString(in) {
+			return true
+		}
+	}
+	return false
+}
+
+// globRegexps compiles the patterns with globRegexp.
+func globRegexps(patterns []string) []*regexp.Regexp {
+	out := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		out = append(out, globRegexp(p))
+	}
+	return out
+}
+
+// globRegexp turns a pattern with * wildcards into an anchored regexp.
+func globRegexp(pattern string) *regexp.Regexp {
+	parts := strings.Split(pattern, "*")
+	for i, p := range parts {
+		parts[i] = regexp.QuoteMeta(p)
+	}
+	return regexp.MustCompile("^" + strings.Join(parts, ".*") + "$")
+}
+
+type targetMatcher struct {
+	cidr *net.IPNet
+	host *regexp.Regexp
+}
+
+func newTargetMatcher(in string) targetMatcher {
+	if _, cidr, err := net.ParseCIDR(in); err == nil {
+		return targetMatcher{cidr: cidr}
+	}
+	return targetMatcher{host: globRegexp(strings.ToLower(in))}
+}
+
+func (t targetMatcher) match(host string) bool {
+	if t.cidr != nil {
+		ip := net.ParseIP(host)
+		return ip != nil && t.cidr.Contains(ip)
+	}
+	return t.host.MatchString(strings.ToLower(host))
+}
+
+// targetHost returns the host of a dial target like dns:///host:port or
+// host:port.
+func targetHost(target string) string {
+	target = dialAddress(target)
+	if host, _, err := net.SplitHostPort(target); err == nil {
+		return host
+	}
+	return strings.Trim(target, "[]")
+}
@@ -0,0 +1,160 @@
+package policy
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const paymentsPolicy = `{
+  "rules": [
+    {"name": "payments-team", "effect": "allow", "groups": ["payments"], "methods": ["payments.*"]},
+    {
+      "name": "payments-writes-on-prod",
+      "methods": ["payments.*"],
+      "targets": ["*.prod.internal", "10.10.0.0/16"],
+      "idempotency_levels": ["IDEMPOTENCY_UNKNOWN", "IDEMPOTENT"]
+    },
+    {"name": "old-revisions", "revisions": ["v0.*"]}
+  ]
+}`
+
+func TestPolicyEvaluate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte(paymentsPolicy), 0600))
+	p, err := Load(path)
+	require.NoError(t, err)
+
+	refund := func(target, level string, groups ...string) *Request {
+		return &Request{
+			Subject:          "alice",
+			Groups:           groups,
+			Service:          "payments.v1.PaymentService",
+			Method:           "Refund",
+			Target:           target,
+			IdempotencyLevel: level,
+		}
+	}
+
+	d := p.Evaluate(refund("dns:///api.prod.internal:9000", "IDEMPOTENCY_UNKNOWN"))
+	assert.False(t, d.Allowed())
+	assert.Equal(t, "payments-writes-on-prod", d.Rule)
+	assert.False(t, p.Evaluate(refund("10.10.3.4:9000", "IDEMPOTENT")).Allowed())
+	assert.True(t, p.Evaluate(refund("10.10.3.4:9000", "NO_SIDE_EFFECTS")).Allowed())
+	assert.True(t, p.Evaluate(refund("api.staging.internal:9000", "IDEMPOTENCY_UNKNOWN")).Allowed())
+
+	d = p.Evaluate(refund("api.prod.internal:9000", "IDEMPOTENCY_UNKNOWN", "dev", "payments"))
+	assert.True(t, d.Allowed())
+	assert.Equal(t, "payments-team", d.Rule)
+
+	assert.False(t, p.Evaluate(&Request{Service: "users.v1.UserService", Method: "Get", Revision: "v0.9"}).Allowed())
+	d = p.Evaluate(&Request{Service: "users.v1.UserService", Method: "Get", Revision: "v1.0"})
+	assert.True(t, d.Allowed())
+	assert.Equal(t, "allow by rule default", d.String())
+
+	p.DryRun = true
+	d = p.Evaluate(refund("api.prod.internal:9000", "IDEMPOTENCY_UNKNOWN"))
+	assert.Equal(t, Deny, d.Effect)
+	assert.True(t, d.Allowed())
+}
+
+func TestPolicyCompile(t *testing.T) {
+	p := &Policy{Default: Deny, Rules: []*Rule{{Effect: Allow, Users: []string{"ci-*"}}}}
+	require.NoError(t, p.Compile())
+	assert.Equal(t, "rule-0", p.Rules[0].Name)
+	assert.True(t, p.Evaluate(&Request{Subject: "ci-deploy"}).Allowed())
+	assert.False(t, p.Evaluate(&Request{}).Allowed())
+
+	assert.Error(t, (&Policy{Rules: []*Rule{{Effect: "maybe"}}}).Compile())
+}
+
+func TestReadOnly(t *testing.T) {
+	var none *ReadOnly
+	assert.False(t, none.AppliesTo("api.prod.internal:9000"))
+	assert.False(t, none.Configured())
+
+	r := &ReadOnly{Targets: []string{"*.prod.internal"}, Allow: []string{"search.*/Query"}}
+	r.Compile()
+	assert.True(t, r.Configured())
+	assert.True(t, r.AppliesTo("dns:///api.prod.internal:9000"))
+	assert.False(t, r.AppliesTo("127.0.0.1:9000"))
+	assert.False(t, r.AppliesTo(""))
+
+	assert.True(t, r.IsSafe("payments.v1.PaymentService", "Refund", "NO_SIDE_EFFECTS"))
+	assert.True(t, r.IsSafe("payments.v1.PaymentService", "GetRefund", "IDEMPOTENCY_UNKNOWN"))
+	assert.True(t, r.IsSafe("search.v1.SearchService", "Query", "IDEMPOTENCY_UNKNOWN"))
+	assert.False(t, r.IsSafe("payments.v1.PaymentService", "Refund", "IDEMPOTENT"))
+
+	r.SafePrefixes = []string{"Fetch"}
+	assert.False(t, r.IsSafe("payments.v1.PaymentService", "GetRefund", "IDEMPOTENCY_UNKNOWN"))
+
+	r.Enabled = true
+	assert.True(t, r.AppliesTo(""))
+}
+
+func TestTargetGuard(t *testing.T) {
+	g := &TargetGuard{
+		DenyCIDRs:  []string{"169.254.0.0/16"},
+		AllowCIDRs: []string{"127.0.0.0/8", "10.0.0.0/8"},
+		DenyHosts:  []string{"*.internal"},
+		AllowPorts: []string{"9000-9100", "443"},
+		DenyPorts:  []string{"9050"},
+	}
+	require.NoError(t, g.Compile())
+
+	assert.NoError(t, g.CheckAddress("10.1.2.3:9000"))
+	assert.NoError(t, g.CheckAddress("dns:///api.example.com:443"))
+	assert.Error(t, g.CheckAddress("10.1.2.3:22"))
+	assert.Error(t, g.CheckAddress("10.1.2.3:9050"))
+	assert.Error(t, g.CheckAddress("10.1.2.3"))
+	assert.Error(t, g.CheckAddress("192.168.1.1:9000"))
+	assert.Error(t, g.CheckAddress("169.254.169.254:443"))
+	assert.Error(t, g.CheckAddress("DB.Internal.:9000"))
+
+	assert.NoError(t, g.Check(context.Background(), net.DefaultResolver, "localhost:9000"))
+	g.DenyCIDRs = append(g.DenyCIDRs, "127.0.0.0/8", "::1/128")
+	require.NoError(t, g.Compile())
+	err := g.Check(context.Background(), net.DefaultResolver, "localhost:9000")
+	var blocked *TargetBlockedError
+	require.True(t, errors.As(err, &blocked))
+	assert.Equal(t, "localhost:9000", blocked.Target)
+
+	assert.Error(t, (&TargetGuard{AllowPorts: []string{"9100-9000"}}).Compile())
+	assert.Error(t, (&TargetGuard{DenyCIDRs: []string{"10.0.0.0"}}).Compile())
+	assert.Error(t, DefaultTargetGuard().CheckAddress("169.254.169.254:80"))
+}
+
+func TestDefaultTargetGuard(t *testing.T) {
+	// the defaults are opt-in, a plain guard reaches internal backends
+	plain := &TargetGuard{}
+	require.NoError(t, plain.Compile())
+	assert.NoError(t, plain.CheckAddress("127.0.0.1:9000"))
+	assert.NoError(t, plain.CheckAddress("10.1.2.3:9000"))
+
+	g := DefaultTargetGuard()
+	for _, target := range []string{
+		"127.0.0.1:9000", "[::1]:9000", "10.1.2.3:9000", "172.20.0.1:9000", "192.168.1.1:9000",
+		"0.0.0.0:9000", "[fd00:ec2::254]:80", "[fe80::1]:9000", "[::ffff:127.0.0.1]:9000",
+	} {
+		assert.Error(t, g.CheckAddress(target), target)
+	}
+	assert.NoError(t, g.CheckAddress("8.8.8.8:443"))
+	assert.NoError(t, g.CheckAddress("[2001:db8::1]:443"))
+	assert.Error(t, g.Check(context.Background(), net.DefaultResolver, "localhost:9000"))
+
+	// the defaults are merged into a policy guard, allowed ranges stay reachable
+	merged := &TargetGuard{AllowCIDRs: []string{"10.1.0.0/16", "0.0.0.0/0"}, DenyCIDRs: []string{"203.0.113.0/24"}}
+	require.NoError(t, merged.MergeDefaults())
+	assert.NoError(t, merged.CheckAddress("10.1.2.3:9000"))
+	assert.Error(t, merged.CheckAddress("10.2.0.1:9000"))
+	assert.Error(t, merged.CheckAddress("127.0.0.1:9000"))
+	assert.Error(t, merged.CheckAddress("169.254.169.254:80"))
+	assert.Error(t, merged.CheckAddress("203.0.113.1:443"))
+	assert.NoError(t, merged.CheckAddress("8.8.8.8:443"))
+}
@@ -0,0 +1,86 @@
+package policy
+
+import (
+	"regexp"
+	"strings"
+)
+
+const noSideEffects = "NO_SIDE_EFFECTS"
+
+// DefaultSafePrefixes are the method name prefixes treated as free of side
+// effects if ReadOnly.SafePrefixes is not set.
+var DefaultSafePrefixes = []string{"Get", "List"}
+
+// ReadOnly only lets methods without side effects through, those marked with
+// idempotency_level = NO_SIDE_EFFECTS, allowed explicitly or named like a
+// read.
+type ReadOnly struct {
+	// Enabled applies the read-only mode to every target.
+	Enabled bool `json:"enabled"`
+	// Targets are CIDRs or host patterns of the targets in read-only mode,
+	// e.g. the production environment.
+	Targets []string `json:"targets,omitempty"`
+	// Allow are method patterns which are safe anyway, e.g.
+	// search.v1.SearchService/Query.
+	Allow        []string `json:"allow,omitempty"`
+	SafePrefixes []string `json:"safe_prefixes,omitempty"`
+
+	targets []targetMatcher
+	allow   []*regexp.Regexp
+}
+
+// Compile prepares the target and method patterns, it is needed for read-only
+// modes built in code.
+func (r *ReadOnly) Compile() {
+	r.targets = r.targets[:0]
+	for _, t := range r.Targets {
+		r.targets = append(r.targets, newTargetMatcher(t))
+	}
+	r.allow = globRegexps(r.Allow)
+}
+
+// AppliesTo reports whether the read-only mode applies to the target, an empty
+// target is the one chosen by the user.
+func (r *ReadOnly) AppliesTo(target string) bool {
+	if r == nil {
+		return false
+	}
+	if r.Enabled {
+		return true
+	}
+	host := targetHost(target)
+	if host == "" {
+		return false
+	}
+	for _, t := range r.targets {
+		if t.match(host) {
+			return true
+		}
+	}
+	return false
+}
+
+// Configured reports whether any target is in read-only mode.
+func (r *ReadOnly) Configured() bool {
+	return r != nil && (r.Enabled || len(r.Targets) > 0)
+}
+
+// IsSafe reports whether the method may be invoked in read-only mode.
+func (r *ReadOnly) IsSafe(service, method, idempotencyLevel string) bool {
+	if idempotencyLevel == noSideEffects {
+		return true
+	}
+	if matchAny(r.allow, service+"/"+method) {
+		return true
+	}
+	prefixes := r.SafePrefixes
+	if len(prefixes) <= 0 {
+		prefixes = DefaultSafePrefixes
+	}
+	for _, p := range prefixes {
+		if strings.HasPrefix(method, p) {
+			return true
+		}
+	}
+	return false
+}
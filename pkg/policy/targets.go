@@ -0,0 +1,277 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// TargetGuard restricts the targets given by users, so that berrypost can not
+// be used to reach arbitrary internal hosts. Deny lists win over allow lists,
+// a non-empty allow list has to match.
+type TargetGuard struct {
+	// DisableUserDefinedTargets only allows targets from the resolvers.
+	DisableUserDefinedTargets bool     `json:"disable_user_defined_targets"`
+	AllowCIDRs                []string `json:"allow_cidrs,omitempty"`
+	DenyCIDRs                 []string `json:"deny_cidrs,omitempty"`
+	// AllowHosts and DenyHosts are host name patterns, e.g. *.staging.internal.
+	AllowHosts []string `json:"allow_hosts,omitempty"`
+	DenyHosts  []string `json:"deny_hosts,omitempty"`
+	// AllowPorts and DenyPorts are ports or port ranges, e.g. 9000-9100.
+	AllowPorts []string `json:"allow_ports,omitempty"`
+	DenyPorts  []string `json:"deny_ports,omitempty"`
+
+	allowCIDRs, denyCIDRs []*net.IPNet
+	defaultDenyCIDRs      []*net.IPNet
+	allowHosts, denyHosts []*regexp.Regexp
+	allowPorts, denyPorts []portRange
+}
+
+// DefaultDenyCIDRs are the loopback, private, link-local and unspecified
+// networks: the metadata services of cloud providers and whatever listens next
+// to berrypost. IPv4-mapped IPv6 addresses are checked as the IPv4 address they
+// map, and rejected when given literally.
+var DefaultDenyCIDRs = []string{
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"::1/128",
+	"fc00::/7",
+	"fe80::/10",
+}
+
+// DefaultTargetGuard denies the DefaultDenyCIDRs, for servers exposed to users
+// who must not reach the network berrypost runs in.
+func DefaultTargetGuard() *TargetGuard {
+	g := &TargetGuard{}
+	if err := g.MergeDefaults(); err != nil {
+		panic(err)
+	}
+	return g
+}
+
+// MergeDefaults adds the DefaultDenyCIDRs to the guard. An allow CIDR within
+// a default one is an exception to it: allowing 10.1.0.0/16 keeps it reachable
+// while the rest of 10.0.0.0/8 stays denied.
+func (g *TargetGuard) MergeDefaults() (err error) {
+	if g.defaultDenyCIDRs, err = parseCIDRs(DefaultDenyCIDRs); err != nil {
+		return err
+	}
+	return g.Compile()
+}
+
+// deniedByDefault tells whether ip is in a default deny CIDR, and not in an
+// allow CIDR within it.
+func (g *TargetGuard) deniedByDefault(ip net.IP) bool {
+	for _, deny := range g.defaultDenyCIDRs {
+		if !deny.Contains(ip) {
+			continue
+		}
+		bits, _ := deny.Mask.Size()
+		excepted := false
+		for _, allow := range g.allowCIDRs {
+			allowBits, _ := allow.Mask.Size()
+			if allow.Contains(ip) && deny.Contains(allow.IP) && allowBits >= bits {
+				excepted = true
+				break
+			}
+		}
+		if !excepted {
+			return true
+		}
+	}
+	return false
+}
+
+// TargetBlockedError is returned for targets rejected by the guard.
+type TargetBlockedError struct {
+	Target string
+	Reason string
+}
+
+func (e *TargetBlockedError) Error() string {
+	return fmt.Sprintf("target %q is blocked: %s", e.Target, e.Reason)
+}
+
+// Temporary is false, so that gRPC gives up dialing.
+func (e *TargetBlockedError) Temporary() bool { return false }
+
+type portRange struct {
+	from, to int
+}
+
+func parsePortRange(in string) (portRange, error) {
+	from, to := in, in
+	if pos := strings.Index(in, "-"); pos >= 0 {
+		from, to = in[:pos], in[pos+1:]
+	}
+	f, err := strconv.Atoi(strings.TrimSpace(from))
+	if err != nil {
+		return portRange{}, errors.Errorf("invalid port range %q", in)
+	}
+	t, err := strconv.Atoi(strings.TrimSpace(to))
+	if err != nil || t < f {
+		return portRange{}, errors.Errorf("invalid port range %q", in)
+	}
+	return portRange{f, t}, nil
+}
+
+func parseCIDRs(in []string) ([]*net.IPNet, error) {
+	out := make([]*net.IPNet, 0, len(in))
+	for _, c := range in {
+		_, cidr, err := net.ParseCIDR(c)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid cidr %q", c)
+		}
+		out = append(out, cidr)
+	}
+	return out, nil
+}
+
+func parsePortRanges(in []string) ([]portRange, error) {
+	out := make([]portRange, 0, len(in))
+	for _, p := range in {
+		r, err := parsePortRange(p)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, r)
+	}
+	return out, nil
+}
+
+// Compile parses the CIDRs, host patterns and port ranges.
+func (g *TargetGuard) Compile() (err error) {
+	if g.allowCIDRs, err = parseCIDRs(g.AllowCIDRs); err != nil {
+		return err
+	}
+	if g.denyCIDRs, err = parseCIDRs(g.DenyCIDRs); err != nil {
+		return err
+	}
+	g.allowHosts = hostRegexps(g.AllowHosts)
+	g.denyHosts = hostRegexps(g.DenyHosts)
+	if g.allowPorts, err = parsePortRanges(g.AllowPorts); err != nil {
+		return err
+	}
+	if g.denyPorts, err = parsePortRanges(g.DenyPorts); err != nil {
+		return err
+	}
+	return nil
+}
+
+// hostRegexps compiles host name patterns, which match case insensitively.
+func hostRegexps(patterns []string) []*regexp.Regexp {
+	out := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		out = append(out, globRegexp(strings.ToLower(p)))
+	}
+	return out
+}
+
+func inPorts(ranges []portRange, port int) bool {
+	for _, r := range ranges {
+		if port >= r.from && port <= r.to {
+			return true
+		}
+	}
+	return false
+}
+
+func inCIDRs(cidrs []*net.IPNet, ip net.IP) bool {
+	for _, c := range cidrs {
+		if c.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// dialAddress returns the host:port of a target like dns:///host:port.
+func dialAddress(target string) string {
+	if pos := strings.Index(target, "://"); pos >= 0 {
+		return strings.TrimLeft(target[pos+3:], "/")
+	}
+	return target
+}
+
+// CheckAddress checks the host name and port of a target like host:port.
+func (g *TargetGuard) CheckAddress(target string) error {
+	if g == nil {
+		return nil
+	}
+	host, portStr, err := net.SplitHostPort(dialAddress(target))
+	if err != nil {
+		return &TargetBlockedError{Target: target, Reason: "port is required"}
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return &TargetBlockedError{Target: target, Reason: "invalid port"}
+	}
+	if inPorts(g.denyPorts, port) {
+		return &TargetBlockedError{Target: target, Reason: fmt.Sprintf("port %d is denied", port)}
+	}
+	if len(g.allowPorts) > 0 && !inPorts(g.allowPorts, port) {
+		return &TargetBlockedError{Target: target, Reason: fmt.Sprintf("port %d is not allowed", port)}
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		// a literal ::ffff:10.0.0.1 is only ever a way around the rules
+		if strings.Contains(host, ":") && ip.To4() != nil {
+			return &TargetBlockedError{Target: target, Reason: fmt.Sprintf("IPv4-mapped address %s is denied", host)}
+		}
+		return g.CheckIP(target, ip)
+	}
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if matchAny(g.denyHosts, host) {
+		return &TargetBlockedError{Target: target, Reason: fmt.Sprintf("host %s is denied", host)}
+	}
+	if len(g.AllowHosts) > 0 && !matchAny(g.allowHosts, host) {
+		return &TargetBlockedError{Target: target, Reason: fmt.Sprintf("host %s is not allowed", host)}
+	}
+	return nil
+}
+
+// CheckIP checks an address the target resolves or is connected to.
+func (g *TargetGuard) CheckIP(target string, ip net.IP) error {
+	if g == nil {
+		return nil
+	}
+	if inCIDRs(g.denyCIDRs, ip) || g.deniedByDefault(ip) {
+		return &TargetBlockedError{Target: target, Reason: fmt.Sprintf("address %s is denied", ip)}
+	}
+	if len(g.allowCIDRs) > 0 && !inCIDRs(g.allowCIDRs, ip) {
+		return &TargetBlockedError{Target: target, Reason: fmt.Sprintf("address %s is not allowed", ip)}
+	}
+	return nil
+}
+
+// Check checks the target and every address its host resolves to.
+func (g *TargetGuard) Check(ctx context.Context, resolver *net.Resolver, target string) error {
+	if g == nil {
+		return nil
+	}
+	if err := g.CheckAddress(target); err != nil {
+		return err
+	}
+	host, _, _ := net.SplitHostPort(dialAddress(target))
+	if net.ParseIP(host) != nil {
+		return nil
+	}
+	addrs, err := resolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return errors.Wrapf(err, "resolve target %q", target)
+	}
+	for _, addr := range addrs {
+		if err := g.CheckIP(target, addr.IP); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,874 @@
+package protohelper
+
+import (
+	"bytes"
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// Option extensions carrying field constraints, protovalidate and its
+// predecessor protoc-gen-validate use the same rule names for most types.
+const (
+	ProtovalidateSource = "buf.validate"
+	PGVSource           = "validate"
+
+	protovalidateFieldRules = "buf.validate.field"
+	protovalidateOneofRules = "buf.validate.oneof"
+	protovalidateMessage    = "buf.validate.message"
+	pgvFieldRules           = "validate.rules"
+	pgvOneofRequired        = "validate.required"
+	pgvMessageDisabled      = "validate.disabled"
+	pgvMessageIgnored       = "validate.ignored"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+type ConstraintViolation struct {
+	Path    string `json:"path"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+type FieldConstraint struct {
+	Path   string          `json:"path"`
+	Source string          `json:"source"`
+	Rules  json.RawMessage `json:"rules"`
+	// Unchecked lists rules which are shown but not evaluated by berrypost,
+	// such as CEL expressions.
+	Unchecked []string `json:"unchecked,omitempty"`
+}
+
+// ConstraintChecker evaluates the protovalidate and PGV field constraints of
+// a message. The rule definitions are read from the imports of the message's
+// file, so messages without constraints cost only a descriptor walk. The
+// resolved options are kept, a checker is meant to be reused for its message,
+// see ConstraintCheckers.
+type ConstraintChecker struct {
+	types *protoregistry.Types
+	now   func() time.Time
+
+	mu      sync.RWMutex
+	options map[protoreflect.Descriptor]proto.Message
+}
+
+func NewConstraintChecker(md protoreflect.MessageDescriptor) *ConstraintChecker {
+	return &ConstraintChecker{
+		types:   NewDynamicTypes(md.ParentFile()),
+		now:     time.Now,
+		options: map[protoreflect.Descriptor]proto.Message{},
+	}
+}
+
+// ConstraintCheckers keeps the checkers of the size most recently checked
+// messages, so that their rule types and options are resolved once. Proto
+// reloads produce new descriptors, the checkers of the old ones age out.
+type ConstraintCheckers struct {
+	mu      sync.Mutex
+	size    int
+	lru     *list.List
+	entries map[protoreflect.MessageDescriptor]*list.Element
+}
+
+type constraintCheckerEntry struct {
+	md      protoreflect.MessageDescriptor
+	checker *ConstraintChecker
+}
+
+func NewConstraintCheckers(size int) *ConstraintCheckers {
+	return &ConstraintCheckers{
+		size:    size,
+		lru:     list.New(),
+		entries: map[protoreflect.MessageDescriptor]*list.Element{},
+	}
+}
+
+// Get returns the checker of the message, creating it if it is not kept.
+func (c *ConstraintCheckers) Get(md protoreflect.MessageDescriptor) *ConstraintChecker {
+	if checker, ok := c.lookup(md); ok {
+		return checker
+	}
+	// built outside the lock, a concurrent build of the same message loses
+	checker := NewConstraintChecker(md)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[md]; ok {
+		return elem.Value.(*constraintCheckerEntry).checker
+	}
+	c.entries[md] = c.lru.PushFront(&constraintCheckerEntry{md: md, checker: checker})
+	for c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*constraintCheckerEntry).md)
+	}
+	return checker
+}
+
+func (c *ConstraintCheckers) lookup(md protoreflect.MessageDescriptor) (*ConstraintChecker, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[md]
+	if !ok {
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	return elem.Value.(*constraintCheckerEntry).checker, true
+}
+
+// resolvedOptions returns the options of the descriptor with the custom
+// options resolved, nil if it has none.
+func (c *ConstraintChecker) resolvedOptions(d protoreflect.Descriptor) proto.Message {
+	c.mu.RLock()
+	resolved, ok := c.options[d]
+	c.mu.RUnlock()
+	if ok {
+		return resolved
+	}
+	opts := d.Options()
+	// custom options are kept as unknown fields unless already resolved
+	if opts != nil && opts.ProtoReflect().IsValid() &&
+		(len(opts.ProtoReflect().GetUnknown()) > 0 || hasExtensions(opts.ProtoReflect())) {
+		if out, err := ResolveOptions(opts, c.types); err == nil {
+			resolved = out
+		}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.options[d] = resolved
+	return resolved
+}
+
+func (c *ConstraintChecker) extensionOf(d protoreflect.Descriptor, names ...protoreflect.FullName) (protoreflect.FullName, protoreflect.Value, bool) {
+	resolved := c.resolvedOptions(d)
+	if resolved == nil {
+		return "", protoreflect.Value{}, false
+	}
+	var (
+		foundName  protoreflect.FullName
+		foundValue protoreflect.Value
+	)
+	resolved.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if !fd.IsExtension() {
+			return true
+		}
+		for _, name := range names {
+			if fd.FullName() == name {
+				foundName, foundValue = name, v
+				return false
+			}
+		}
+		return true
+	})
+	return foundName, foundValue, foundName != ""
+}
+
+func hasExtensions(m protoreflect.Message) bool {
+	found := false
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		found = fd.IsExtension()
+		return !found
+	})
+	return found
+}
+
+// fieldRules returns the constraint message attached to the field.
+func (c *ConstraintChecker) fieldRules(fd protoreflect.FieldDescriptor) (string, protoreflect.Message, bool) {
+	name, v, ok := c.extensionOf(fd, protovalidateFieldRules, pgvFieldRules)
+	if !ok {
+		return "", nil, false
+	}
+	if name == protovalidateFieldRules {
+		return ProtovalidateSource, v.Message(), true
+	}
+	return PGVSource, v.Message(), true
+}
+
+func (c *ConstraintChecker) messageDisabled(md protoreflect.MessageDescriptor) bool {
+	name, v, ok := c.extensionOf(md, protovalidateMessage, pgvMessageDisabled, pgvMessageIgnored)
+	if !ok {
+		return false
+	}
+	if name == protovalidateMessage {
+		disabled, ok := ruleValue(v.Message(), "disabled")
+		return ok && disabled.Bool()
+	}
+	return v.Bool()
+}
+
+func (c *ConstraintChecker) oneofRequired(od protoreflect.OneofDescriptor) bool {
+	name, v, ok := c.extensionOf(od, protovalidateOneofRules, pgvOneofRequired)
+	if !ok {
+		return false
+	}
+	if name == protovalidateOneofRules {
+		required, ok := ruleValue(v.Message(), "required")
+		return ok && required.Bool()
+	}
+	return v.Bool()
+}
+
+func ruleValue(rules protoreflect.Message, name protoreflect.Name) (protoreflect.Value, bool) {
+	fd := rules.Descriptor().Fields().ByName(name)
+	if fd == nil || !rules.Has(fd) {
+		return protoreflect.Value{}, false
+	}
+	return rules.Get(fd), true
+}
+
+// typeRules returns the type specific member of the rules, e.g. string.
+func typeRules(rules protoreflect.Message) (string, protoreflect.Message, bool) {
+	od := rules.Descriptor().Oneofs().ByName("type")
+	if od == nil {
+		return "", nil, false
+	}
+	fd := rules.WhichOneof(od)
+	if fd == nil || fd.Message() == nil {
+		return "", nil, false
+	}
+	return string(fd.Name()), rules.Get(fd).Message(), true
+}
+
+// Check returns the violations of the message and the messages nested in it.
+func (c *ConstraintChecker) Check(m protoreflect.Message) []*ConstraintViolation {
+	w := &constraintWalker{checker: c, stack: map[protoreflect.FullName]int{}}
+	w.message(m, "")
+	return w.violations
+}
+
+type constraintWalker struct {
+	checker    *ConstraintChecker
+	violations []*ConstraintViolation
+	stack      map[protoreflect.FullName]int
+}
+
+func (w *constraintWalker) fail(path, rule, format string, args ...interface{}) {
+	w.violations = append(w.violations, &ConstraintViolation{
+		Path:    rootPath(path),
+		Rule:    rule,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (w *constraintWalker) message(m protoreflect.Message, path string) {
+	md := m.Descriptor()
+	if md.FullName().Parent() == "google.protobuf" || w.checker.messageDisabled(md) {
+		return
+	}
+
+	oneofs := md.Oneofs()
+	for i := 0; i < oneofs.Len(); i++ {
+		od := oneofs.Get(i)
+		if !od.IsSynthetic() && w.checker.oneofRequired(od) && m.WhichOneof(od) == nil {
+			w.fail(joinTemplatePath(path, string(od.Name())), "required", "exactly one field of oneof %s is required", od.Name())
+		}
+	}
+
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		fieldPath := joinTemplatePath(path, fd.JSONName())
+		_, rules, ok := w.checker.fieldRules(fd)
+		if ok {
+			if !w.field(m, fd, rules, fieldPath) {
+				continue
+			}
+		}
+		w.nested(m, fd, rules, fieldPath)
+	}
+}
+
+// nested walks into message values unless the rules say to skip them.
+func (w *constraintWalker) nested(m protoreflect.Message, fd protoreflect.FieldDescriptor, rules protoreflect.Message, path string) {
+	if rules != nil {
+		if name, tr, ok := typeRules(rules); ok && name == "message" {
+			if skip, ok := ruleValue(tr, "skip"); ok && skip.Bool() {
+				return
+			}
+		}
+	}
+	if !m.Has(fd) {
+		return
+	}
+	switch {
+	case fd.IsMap():
+		if fd.MapValue().Message() == nil {
+			return
+		}
+		m.Get(fd).Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			w.enter(v.Message(), fmt.Sprintf("%s[%v]", path, k.Interface()))
+			return true
+		})
+	case fd.IsList():
+		if fd.Message() == nil {
+			return
+		}
+		list := m.Get(fd).List()
+		for i := 0; i < list.Len(); i++ {
+			w.enter(list.Get(i).Message(), fmt.Sprintf("%s[%d]", path, i))
+		}
+	case fd.Message() != nil:
+		w.enter(m.Get(fd).Message(), path)
+	}
+}
+
+// enter walks a nested message, recursive types are followed as long as the
+// message is populated but never more than a fixed number of times.
+func (w *constraintWalker) enter(m protoreflect.Message, path string) {
+	name := m.Descriptor().FullName()
+	if w.stack[name] > 32 {
+		return
+	}
+	w.stack[name]++
+	w.message(m, path)
+	w.stack[name]--
+}
+
+func ignoreIfEmpty(rules protoreflect.Message) (ignoreAlways, ignoreEmpty bool) {
+	if v, ok := ruleValue(rules, "ignore"); ok {
+		fd := rules.Descriptor().Fields().ByName("ignore")
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			name := string(ev.Name())
+			switch {
+			case strings.HasSuffix(name, "_ALWAYS"):
+				return true, false
+			case strings.HasSuffix(name, "_UNPOPULATED"), strings.HasSuffix(name, "_DEFAULT_VALUE"), strings.HasSuffix(name, "_EMPTY"):
+				return false, true
+			}
+		}
+	}
+	if v, ok := ruleValue(rules, "ignore_empty"); ok && v.Bool() {
+		return false, true
+	}
+	if _, tr, ok := typeRules(rules); ok {
+		if v, ok := ruleValue(tr, "ignore_empty"); ok && v.Bool() {
+			return false, true
+		}
+	}
+	return false, false
+}
+
+// field evaluates the rules of a single field, it reports whether nested
+// messages should be checked as well.
+func (w *constraintWalker) field(m protoreflect.Message, fd protoreflect.FieldDescriptor, rules protoreflect.Message, path string) bool {
+	ignoreAlways, ignoreEmpty := ignoreIfEmpty(rules)
+	if ignoreAlways {
+		return false
+	}
+	populated := m.Has(fd)
+	if required, ok := ruleValue(rules, "required"); ok && required.Bool() && !populated {
+		w.fail(path, "required", "value is required")
+		return false
+	}
+	if !populated && ignoreEmpty {
+		return false
+	}
+
+	name, tr, ok := typeRules(rules)
+	if !ok {
+		return true
+	}
+	switch {
+	case fd.IsList() && name == "repeated":
+		w.repeated(fd, m.Get(fd).List(), tr, path)
+	case fd.IsMap() && name == "map":
+		w.mapRules(fd, m.Get(fd).Map(), tr, path)
+	case fd.Message() != nil && !populated:
+		if required, ok := ruleValue(tr, "required"); ok && required.Bool() {
+			w.fail(path, name+".required", "value is required")
+		}
+	case !fd.IsList() && !fd.IsMap():
+		w.value(fd, m.Get(fd), name, tr, path)
+	}
+	return true
+}
+
+func (w *constraintWalker) repeated(fd protoreflect.FieldDescriptor, list protoreflect.List, tr protoreflect.Message, path string) {
+	if v, ok := ruleValue(tr, "min_items"); ok && uint64(list.Len()) < v.Uint() {
+		w.fail(path, "repeated.min_items", "value must contain at least %d item(s)", v.Uint())
+	}
+	if v, ok := ruleValue(tr, "max_items"); ok && uint64(list.Len()) > v.Uint() {
+		w.fail(path, "repeated.max_items", "value must contain no more than %d item(s)", v.Uint())
+	}
+	if v, ok := ruleValue(tr, "unique"); ok && v.Bool() && fd.Message() == nil {
+		seen := map[interface{}]struct{}{}
+		for i := 0; i < list.Len(); i++ {
+			key := list.Get(i).Interface()
+			if b, ok := key.([]byte); ok {
+				key = string(b)
+			}
+			if _, dup := seen[key]; dup {
+				w.fail(fmt.Sprintf("%s[%d]", path, i), "repeated.unique", "repeated value must contain unique items")
+				break
+			}
+			seen[key] = struct{}{}
+		}
+	}
+	items, ok := ruleValue(tr, "items")
+	if !ok {
+		return
+	}
+	for i := 0; i < list.Len(); i++ {
+		w.element(fd, list.Get(i), items.Message(), fmt.Sprintf("%s[%d]", path, i))
+	}
+}
+
+func (w *constraintWalker) mapRules(fd protoreflect.FieldDescriptor, mp protoreflect.Map, tr protoreflect.Message, path string) {
+	if v, ok := ruleValue(tr, "min_pairs"); ok && uint64(mp.Len()) < v.Uint() {
+		w.fail(path, "map.min_pairs", "map must be at least %d entries", v.Uint())
+	}
+	if v, ok := ruleValue(tr, "max_pairs"); ok && uint64(mp.Len()) > v.Uint() {
+		w.fail(path, "map.max_pairs", "map must be at most %d entries", v.Uint())
+	}
+	keys, hasKeys := ruleValue(tr, "keys")
+	values, hasValues := ruleValue(tr, "values")
+	mp.Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+		entryPath := fmt.Sprintf("%s[%v]", path, k.Interface())
+		if hasKeys {
+			w.element(fd.MapKey(), k.Value(), keys.Message(), entryPath)
+		}
+		if hasValues {
+			w.element(fd.MapValue(), v, values.Message(), entryPath)
+		}
+		return true
+	})
+}
+
+// element evaluates the rules of a list item or a map key or value.
+func (w *constraintWalker) element(fd protoreflect.FieldDescriptor, v protoreflect.Value, rules protoreflect.Message, path string) {
+	if ignoreAlways, _ := ignoreIfEmpty(rules); ignoreAlways {
+		return
+	}
+	if name, tr, ok := typeRules(rules); ok {
+		w.value(fd, v, name, tr, path)
+	}
+}
+
+func (w *constraintWalker) value(fd protoreflect.FieldDescriptor, v protoreflect.Value, name string, tr protoreflect.Message, path string) {
+	switch name {
+	case "string":
+		w.stringValue(v.String(), tr, path)
+	case "bytes":
+		w.bytesValue(v.Bytes(), tr, path)
+	case "bool":
+		if c, ok := ruleValue(tr, "const"); ok && c.Bool() != v.Bool() {
+			w.fail(path, "bool.const", "value must equal %t", c.Bool())
+		}
+	case "enum":
+		w.enumValue(fd, v.Enum(), tr, path)
+	case "duration":
+		if fd.Message() != nil {
+			w.durationValue(v.Message(), tr, path)
+		}
+	case "timestamp":
+		if fd.Message() != nil {
+			w.timestampValue(v.Message(), tr, path)
+		}
+	case "any":
+		if fd.Message() != nil {
+			w.anyValue(v.Message(), tr, path)
+		}
+	case "message":
+	default:
+		w.number(v, name, tr, path)
+	}
+}
+
+func (w *constraintWalker) inRules(v interface{}, tr protoreflect.Message, name, path string, equal func(a, b protoreflect.Value) bool, value protoreflect.Value) {
+	if in, ok := ruleValue(tr, "in"); ok {
+		found := false
+		for i := 0; i < in.List().Len(); i++ {
+			if equal(in.List().Get(i), value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			w.fail(path, name+".in", "value %v must be in list %s", v, formatList(in.List()))
+		}
+	}
+	if notIn, ok := ruleValue(tr, "not_in"); ok {
+		for i := 0; i < notIn.List().Len(); i++ {
+			if equal(notIn.List().Get(i), value) {
+				w.fail(path, name+".not_in", "value %v must not be in list %s", v, formatList(notIn.List()))
+				break
+			}
+		}
+	}
+}
+
+func formatList(list protoreflect.List) string {
+	items := make([]string, 0, list.Len())
+	for i := 0; i < list.Len(); i++ {
+		items = append(items, fmt.Sprintf("%v", list.Get(i).Interface()))
+	}
+	return "[" + strings.Join(items, ", ") + "]"
+}
+
+// compareValues orders two numeric values of the same kind.
+func compareValues(a, b protoreflect.Value) int {
+	switch av := a.Interface().(type) {
+	case int32, int64:
+		x, y := a.Int(), b.Int()
+		return compareOrdered(x < y, x > y)
+	case uint32, uint64:
+		x, y := a.Uint(), b.Uint()
+		return compareOrdered(x < y, x > y)
+	case float32, float64:
+		x, y := a.Float(), b.Float()
+		return compareOrdered(x < y, x > y)
+	default:
+		panic(fmt.Sprintf("unexpected numeric value %T", av))
+	}
+}
+
+func compareOrdered(less, greater bool) int {
+	switch {
+	case less:
+		return -1
+	case greater:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// checkRange applies const, lt, lte, gt and gte. Like protovalidate, a lower
+// bound above the upper bound describes an exclusive range.
+func (w *constraintWalker) checkRange(v interface{}, tr protoreflect.Message, name, path string, cmp func(rule protoreflect.Value) int) {
+	if c, ok := ruleValue(tr, "const"); ok && cmp(c) != 0 {
+		w.fail(path, name+".const", "value must equal %v", c.Interface())
+	}
+	type bound struct {
+		rule  string
+		value protoreflect.Value
+		ok    func(int) bool
+		text  string
+	}
+	var lower, upper *bound
+	if r, ok := ruleValue(tr, "gt"); ok {
+		lower = &bound{"gt", r, func(c int) bool { return c > 0 }, "greater than"}
+	} else if r, ok := ruleValue(tr, "gte"); ok {
+		lower = &bound{"gte", r, func(c int) bool { return c >= 0 }, "greater than or equal to"}
+	}
+	if r, ok := ruleValue(tr, "lt"); ok {
+		upper = &bound{"lt", r, func(c int) bool { return c < 0 }, "less than"}
+	} else if r, ok := ruleValue(tr, "lte"); ok {
+		upper = &bound{"lte", r, func(c int) bool { return c <= 0 }, "less than or equal to"}
+	}
+	describe := func(b *bound) string {
+		return fmt.Sprintf("%s %v", b.text, b.value.Interface())
+	}
+	switch {
+	case lower != nil && upper != nil:
+		inLower, inUpper := lower.ok(cmp(lower.value)), upper.ok(cmp(upper.value))
+		if rangeIsExclusive(lower.value, upper.value) {
+			if !inLower && !inUpper {
+				w.fail(path, name+"."+lower.rule+"_"+upper.rule+"_exclusive", "value must be %s or %s", describe(lower), describe(upper))
+			}
+			return
+		}
+		if !inLower || !inUpper {
+			w.fail(path, name+"."+lower.rule+"_"+upper.rule, "value must be %s and %s", describe(lower), describe(upper))
+		}
+	case lower != nil && !lower.ok(cmp(lower.value)):
+		w.fail(path, name+"."+lower.rule, "value must be %s", describe(lower))
+	case upper != nil && !upper.ok(cmp(upper.value)):
+		w.fail(path, name+"."+upper.rule, "value must be %s", describe(upper))
+	}
+}
+
+func rangeIsExclusive(lower, upper protoreflect.Value) bool {
+	if lm, ok := lower.Interface().(protoreflect.Message); ok {
+		um := upper.Interface().(protoreflect.Message)
+		return durationOf(lm) > durationOf(um)
+	}
+	return compareValues(lower, upper) > 0
+}
+
+func (w *constraintWalker) number(v protoreflect.Value, name string, tr protoreflect.Message, path string) {
+	w.checkRange(v.Interface(), tr, name, path, func(rule protoreflect.Value) int {
+		return compareValues(v, rule)
+	})
+	w.inRules(v.Interface(), tr, name, path, func(a, b protoreflect.Value) bool {
+		return compareValues(a, b) == 0
+	}, v)
+}
+
+func (w *constraintWalker) stringValue(s string, tr protoreflect.Message, path string) {
+	runes := uint64(utf8.RuneCountInString(s))
+	if c, ok := ruleValue(tr, "const"); ok && c.String() != s {
+		w.fail(path, "string.const", "value must equal %q", c.String())
+	}
+	if v, ok := ruleValue(tr, "len"); ok && runes != v.Uint() {
+		w.fail(path, "string.len", "value length must be %d characters", v.Uint())
+	}
+	if v, ok := ruleValue(tr, "min_len"); ok && runes < v.Uint() {
+		w.fail(path, "string.min_len", "value length must be at least %d characters", v.Uint())
+	}
+	if v, ok := ruleValue(tr, "max_len"); ok && runes > v.Uint() {
+		w.fail(path, "string.max_len", "value length must be at most %d characters", v.Uint())
+	}
+	if v, ok := ruleValue(tr, "len_bytes"); ok && uint64(len(s)) != v.Uint() {
+		w.fail(path, "string.len_bytes", "value length must be %d bytes", v.Uint())
+	}
+	if v, ok := ruleValue(tr, "min_bytes"); ok && uint64(len(s)) < v.Uint() {
+		w.fail(path, "string.min_bytes", "value length must be at least %d bytes", v.Uint())
+	}
+	if v, ok := ruleValue(tr, "max_bytes"); ok && uint64(len(s)) > v.Uint() {
+		w.fail(path, "string.max_bytes", "value length must be at most %d bytes", v.Uint())
+	}
+	if v, ok := ruleValue(tr, "pattern"); ok {
+		re, err := regexp.Compile(v.String())
+		if err == nil && !re.MatchString(s) {
+			w.fail(path, "string.pattern", "value does not match regex pattern %q", v.String())
+		}
+	}
+	if v, ok := ruleValue(tr, "prefix"); ok && !strings.HasPrefix(s, v.String()) {
+		w.fail(path, "string.prefix", "value does not have prefix %q", v.String())
+	}
+	if v, ok := ruleValue(tr, "suffix"); ok && !strings.HasSuffix(s, v.String()) {
+		w.fail(path, "string.suffix", "value does not have suffix %q", v.String())
+	}
+	if v, ok := ruleValue(tr, "contains"); ok && !strings.Contains(s, v.String()) {
+		w.fail(path, "string.contains", "value does not contain substring %q", v.String())
+	}
+	if v, ok := ruleValue(tr, "not_contains"); ok && strings.Contains(s, v.String()) {
+		w.fail(path, "string.not_contains", "value contains substring %q", v.String())
+	}
+	w.inRules(fmt.Sprintf("%q", s), tr, "string", path, func(a, b protoreflect.Value) bool {
+		return a.String() == b.String()
+	}, protoreflect.ValueOfString(s))
+
+	wellKnown := map[string]func(string) bool{
+		"email":    isEmail,
+		"hostname": isHostname,
+		"ip":       func(s string) bool { return net.ParseIP(s) != nil },
+		"ipv4":     func(s string) bool { ip := net.ParseIP(s); return ip != nil && ip.To4() != nil },
+		"ipv6":     func(s string) bool { ip := net.ParseIP(s); return ip != nil && ip.To4() == nil },
+		"uri":      isURI,
+		"uri_ref":  func(s string) bool { _, err := url.Parse(s); return err == nil },
+		"uuid":     uuidPattern.MatchString,
+		"address":  func(s string) bool { return net.ParseIP(s) != nil || isHostname(s) },
+	}
+	for rule, check := range wellKnown {
+		if v, ok := ruleValue(tr, protoreflect.Name(rule)); ok && v.Bool() && !check(s) {
+			w.fail(path, "string."+rule, "value must be a valid %s", strings.Replace(rule, "_", " ", -1))
+		}
+	}
+}
+
+func isEmail(s string) bool {
+	addr, err := mail.ParseAddress(s)
+	return err == nil && addr.Name == "" && addr.Address == s
+}
+
+func isHostname(s string) bool {
+	s = strings.TrimSuffix(s, ".")
+	if s == "" || len(s) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(s, ".") {
+		if label == "" || len(label) > 63 || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return false
+		}
+		for _, r := range label {
+			if !(r == '-' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func isURI(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && u.IsAbs()
+}
+
+func (w *constraintWalker) bytesValue(b []byte, tr protoreflect.Message, path string) {
+	if c, ok := ruleValue(tr, "const"); ok && !bytes.Equal(c.Bytes(), b) {
+		w.fail(path, "bytes.const", "value must equal %x", c.Bytes())
+	}
+	if v, ok := ruleValue(tr, "len"); ok && uint64(len(b)) != v.Uint() {
+		w.fail(path, "bytes.len", "value length must be %d bytes", v.Uint())
+	}
+	if v, ok := ruleValue(tr, "min_len"); ok && uint64(len(b)) < v.Uint() {
+		w.fail(path, "bytes.min_len", "value length must be at least %d bytes", v.Uint())
+	}
+	if v, ok := ruleValue(tr, "max_len"); ok && uint64(len(b)) > v.Uint() {
+		w.fail(path, "bytes.max_len", "value length must be at most %d bytes", v.Uint())
+	}
+	if v, ok := ruleValue(tr, "pattern"); ok {
+		re, err := regexp.Compile(v.String())
+		if err == nil && (!utf8.Valid(b) || !re.Match(b)) {
+			w.fail(path, "bytes.pattern", "value must match regex pattern %q", v.String())
+		}
+	}
+	if v, ok := ruleValue(tr, "prefix"); ok && !bytes.HasPrefix(b, v.Bytes()) {
+		w.fail(path, "bytes.prefix", "value does not have prefix %x", v.Bytes())
+	}
+	if v, ok := ruleValue(tr, "suffix"); ok && !bytes.HasSuffix(b, v.Bytes()) {
+		w.fail(path, "bytes.suffix", "value does not have suffix %x", v.Bytes())
+	}
+	if v, ok := ruleValue(tr, "contains"); ok && !bytes.Contains(b, v.Bytes()) {
+		w.fail(path, "bytes.contains", "value does not contain %x", v.Bytes())
+	}
+	if v, ok := ruleValue(tr, "ip"); ok && v.Bool() && len(b) != net.IPv4len && len(b) != net.IPv6len {
+		w.fail(path, "bytes.ip", "value must be a valid IP address")
+	}
+	if v, ok := ruleValue(tr, "ipv4"); ok && v.Bool() && len(b) != net.IPv4len {
+		w.fail(path, "bytes.ipv4", "value must be a valid IPv4 address")
+	}
+	if v, ok := ruleValue(tr, "ipv6"); ok && v.Bool() && len(b) != net.IPv6len {
+		w.fail(path, "bytes.ipv6", "value must be a valid IPv6 address")
+	}
+	w.inRules(fmt.Sprintf("%x", b), tr, "bytes", path, func(a, b protoreflect.Value) bool {
+		return bytes.Equal(a.Bytes(), b.Bytes())
+	}, protoreflect.ValueOfBytes(b))
+}
+
+func (w *constraintWalker) enumValue(fd protoreflect.FieldDescriptor, n protoreflect.EnumNumber, tr protoreflect.Message, path string) {
+	if c, ok := ruleValue(tr, "const"); ok && protoreflect.EnumNumber(c.Int()) != n {
+		w.fail(path, "enum.const", "value must equal %d", c.Int())
+	}
+	if v, ok := ruleValue(tr, "defined_only"); ok && v.Bool() && fd.Enum() != nil && fd.Enum().Values().ByNumber(n) == nil {
+		w.fail(path, "enum.defined_only", "value must be one of the defined enum values")
+	}
+	w.inRules(n, tr, "enum", path, func(a, b protoreflect.Value) bool {
+		return a.Int() == int64(b.Enum())
+	}, protoreflect.ValueOfEnum(n))
+}
+
+func durationOf(m protoreflect.Message) time.Duration {
+	fields := m.Descriptor().Fields()
+	seconds, nanos := fields.ByName("seconds"), fields.ByName("nanos")
+	if seconds == nil || nanos == nil {
+		return 0
+	}
+	return time.Duration(m.Get(seconds).Int())*time.Second + time.Duration(m.Get(nanos).Int())
+}
+
+func timeOf(m protoreflect.Message) time.Time {
+	fields := m.Descriptor().Fields()
+	seconds, nanos := fields.ByName("seconds"), fields.ByName("nanos")
+	if seconds == nil || nanos == nil {
+		return time.Time{}
+	}
+	return time.Unix(m.Get(seconds).Int(), m.Get(nanos).Int()).UTC()
+}
+
+func (w *constraintWalker) durationValue(m protoreflect.Message, tr protoreflect.Message, path string) {
+	d := durationOf(m)
+	cmp := func(rule protoreflect.Value) int {
+		r := durationOf(rule.Message())
+		return compareOrdered(d < r, d > r)
+	}
+	w.checkRange(d, tr, "duration", path, cmp)
+	w.inRules(d, tr, "duration", path, func(a, _ protoreflect.Value) bool {
+		return cmp(a) == 0
+	}, protoreflect.Value{})
+}
+
+func (w *constraintWalker) timestampValue(m protoreflect.Message, tr protoreflect.Message, path string) {
+	t := timeOf(m)
+	w.checkRange(t.Format(time.RFC3339Nano), tr, "timestamp", path, func(rule protoreflect.Value) int {
+		r := timeOf(rule.Message())
+		return compareOrdered(t.Before(r), t.After(r))
+	})
+	now := w.checker.now()
+	if v, ok := ruleValue(tr, "lt_now"); ok && v.Bool() && !t.Before(now) {
+		w.fail(path, "timestamp.lt_now", "value must be less than now")
+	}
+	if v, ok := ruleValue(tr, "gt_now"); ok && v.Bool() && !t.After(now) {
+		w.fail(path, "timestamp.gt_now", "value must be greater than now")
+	}
+	if v, ok := ruleValue(tr, "within"); ok {
+		within := durationOf(v.Message())
+		if diff := t.Sub(now); diff > within || diff < -within {
+			w.fail(path, "timestamp.within", "value must be within %s of now", within)
+		}
+	}
+}
+
+func (w *constraintWalker) anyValue(m protoreflect.Message, tr protoreflect.Message, path string) {
+	typeURLField := m.Descriptor().Fields().ByName("type_url")
+	if typeURLField == nil {
+		return
+	}
+	typeURL := m.Get(typeURLField).String()
+	w.inRules(fmt.Sprintf("%q", typeURL), tr, "any", path, func(a, b protoreflect.Value) bool {
+		return a.String() == b.String()
+	}, protoreflect.ValueOfString(typeURL))
+}
+
+// Describe lists the constraints declared on the fields of the message and
+// of the messages it refers to.
+func (c *ConstraintChecker) Describe(md protoreflect.MessageDescriptor) []*FieldConstraint {
+	out := []*FieldConstraint{}
+	seen := map[protoreflect.FullName]struct{}{}
+	var walk func(md protoreflect.MessageDescriptor, path string)
+	walk = func(md protoreflect.MessageDescriptor, path string) {
+		if _, ok := seen[md.FullName()]; ok || md.FullName().Parent() == "google.protobuf" {
+			return
+		}
+		seen[md.FullName()] = struct{}{}
+		oneofs := md.Oneofs()
+		for i := 0; i < oneofs.Len(); i++ {
+			od := oneofs.Get(i)
+			if !od.IsSynthetic() && c.oneofRequired(od) {
+				out = append(out, &FieldConstraint{
+					Path:  joinTemplatePath(path, string(od.Name())),
+					Rules: json.RawMessage(`{"required":true}`),
+				})
+			}
+		}
+		fields := md.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			fd := fields.Get(i)
+			fieldPath := joinTemplatePath(path, fd.JSONName())
+			if source, rules, ok := c.fieldRules(fd); ok {
+				raw, err := protojson.MarshalOptions{Resolver: c.types}.Marshal(rules.Interface())
+				if err != nil {
+					raw = []byte("null")
+				}
+				out = append(out, &FieldConstraint{
+					Path:      fieldPath,
+					Source:    source,
+					Rules:     json.RawMessage(raw),
+					Unchecked: uncheckedRules(rules),
+				})
+			}
+			switch {
+			case fd.IsMap() && fd.MapValue().Message() != nil:
+				walk(fd.MapValue().Message(), fieldPath+"[]")
+			case fd.IsList() && fd.Message() != nil:
+				walk(fd.Message(), fieldPath+"[]")
+			case fd.Message() != nil:
+				walk(fd.Message(), fieldPath)
+			}
+		}
+	}
+	walk(md, "")
+	return out
+}
+
+func uncheckedRules(rules protoreflect.Message) []string {
+	out := []string{}
+	if v, ok := ruleValue(rules, "cel"); ok && v.List().Len() > 0 {
+		out = append(out, "cel")
+	}
+	return out
+}
@@ -0,0 +1,182 @@
+package protohelper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// a trimmed down validate/validate.proto of protoc-gen-validate
+const pgvTestProto = `
+name: "validate/validate.proto"
+package: "validate"
+dependency: "google/protobuf/descriptor.proto"
+message_type: {
+  name: "FieldRules"
+  field: { name: "int64" number: 4 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".validate.Int64Rules" oneof_index: 0 json_name: "int64" }
+  field: { name: "string" number: 14 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".validate.StringRules" oneof_index: 0 json_name: "string" }
+  field: { name: "repeated" number: 18 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".validate.RepeatedRules" oneof_index: 0 json_name: "repeated" }
+  oneof_decl: { name: "type" }
+}
+message_type: {
+  name: "Int64Rules"
+  field: { name: "lt" number: 2 label: LABEL_OPTIONAL type: TYPE_INT64 json_name: "lt" }
+  field: { name: "gt" number: 4 label: LABEL_OPTIONAL type: TYPE_INT64 json_name: "gt" }
+  field: { name: "in" number: 6 label: LABEL_REPEATED type: TYPE_INT64 json_name: "in" }
+}
+message_type: {
+  name: "StringRules"
+  field: { name: "min_len" number: 2 label: LABEL_OPTIONAL type: TYPE_UINT64 json_name: "minLen" }
+  field: { name: "email" number: 12 label: LABEL_OPTIONAL type: TYPE_BOOL json_name: "email" }
+  field: { name: "ignore_empty" number: 26 label: LABEL_OPTIONAL type: TYPE_BOOL json_name: "ignoreEmpty" }
+}
+message_type: {
+  name: "RepeatedRules"
+  field: { name: "min_items" number: 1 label: LABEL_OPTIONAL type: TYPE_UINT64 json_name: "minItems" }
+  field: { name: "items" number: 4 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".validate.FieldRules" json_name: "items" }
+}
+extension: { name: "rules" number: 1071 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".validate.FieldRules" extendee: ".google.protobuf.FieldOptions" json_name: "rules" }
+extension: { name: "required" number: 1071 label: LABEL_OPTIONAL type: TYPE_BOOL extendee: ".google.protobuf.OneofOptions" json_name: "required" }
+`
+
+const constrainedTestProto = `
+name: "demo/constrained.proto"
+package: "demo"
+syntax: "proto3"
+dependency: "validate/validate.proto"
+message_type: {
+  name: "CreateUser"
+  field: { name: "id" number: 1 label: LABEL_OPTIONAL type: TYPE_INT64 json_name: "id" }
+  field: { name: "email" number: 2 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "email" }
+  field: { name: "nickname" number: 3 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "nickname" }
+  field: { name: "tags" number: 4 label: LABEL_REPEATED type: TYPE_STRING json_name: "tags" }
+  field: { name: "friends" number: 5 label: LABEL_REPEATED type: TYPE_MESSAGE type_name: ".demo.CreateUser" json_name: "friends" }
+  field: { name: "phone" number: 6 label: LABEL_OPTIONAL type: TYPE_STRING oneof_index: 0 json_name: "phone" }
+  oneof_decl: { name: "contact" }
+}
+`
+
+func mustTestFile(t *testing.T, files *protoregistry.Files, textproto string, patch func(*descriptorpb.FileDescriptorProto)) protoreflect.FileDescriptor {
+	fdp := &descriptorpb.FileDescriptorProto{}
+	require.NoError(t, prototext.Unmarshal([]byte(textproto), fdp))
+	if patch != nil {
+		patch(fdp)
+	}
+	fd, err := protodesc.NewFile(fdp, files)
+	require.NoError(t, err)
+	require.NoError(t, files.RegisterFile(fd))
+	return fd
+}
+
+// customOption encodes a message as the extension field number of an options
+// message, the way protoc stores custom options.
+func customOption(t *testing.T, md protoreflect.MessageDescriptor, number protowire.Number, jsonValue string) []byte {
+	m := dynamicpb.NewMessage(md)
+	require.NoError(t, protojson.Unmarshal([]byte(jsonValue), m))
+	raw, err := proto.Marshal(m)
+	require.NoError(t, err)
+	out := protowire.AppendTag(nil, number, protowire.BytesType)
+	return protowire.AppendBytes(out, raw)
+}
+
+func newConstrainedMessage(t *testing.T) protoreflect.MessageDescriptor {
+	files := &protoregistry.Files{}
+	descriptorFile, err := protoregistry.GlobalFiles.FindFileByPath("google/protobuf/descriptor.proto")
+	require.NoError(t, err)
+	require.NoError(t, files.RegisterFile(descriptorFile))
+	validate := mustTestFile(t, files, pgvTestProto, nil)
+	fieldRules := validate.Messages().ByName("FieldRules")
+
+	rules := map[string]string{
+		"id":       `{"int64": {"gt": "0", "lt": "100"}}`,
+		"email":    `{"string": {"email": true}}`,
+		"nickname": `{"string": {"minLen": "3", "ignoreEmpty": true}}`,
+		"tags":     `{"repeated": {"minItems": "1", "items": {"string": {"minLen": "2"}}}}`,
+	}
+	return mustTestFile(t, files, constrainedTestProto, func(fdp *descriptorpb.FileDescriptorProto) {
+		msg := fdp.MessageType[0]
+		for _, f := range msg.Field {
+			if r, ok := rules[f.GetName()]; ok {
+				f.Options = &descriptorpb.FieldOptions{}
+				f.Options.ProtoReflect().SetUnknown(customOption(t, fieldRules, 1071, r))
+			}
+		}
+		msg.OneofDecl[0].Options = &descriptorpb.OneofOptions{}
+		msg.OneofDecl[0].Options.ProtoReflect().SetUnknown(protowire.AppendVarint(protowire.AppendTag(nil, 1071, protowire.VarintType), 1))
+	}).Messages().ByName("CreateUser")
+}
+
+func TestConstraintCheckerPGV(t *testing.T) {
+	md := newConstrainedMessage(t)
+	checker := NewConstraintChecker(md)
+
+	m := dynamicpb.NewMessage(md)
+	require.NoError(t, protojson.Unmarshal([]byte(`{
+		"id": "100",
+		"email": "not an email",
+		"tags": ["a"],
+		"friends": [{"id": "1", "email": "a@example.com", "tags": ["ok"], "nickname": "al", "phone": "1"}]
+	}`), m))
+	got := map[string]string{}
+	for _, v := range checker.Check(m) {
+		got[v.Path] = v.Rule
+	}
+	assert.Equal(t, map[string]string{
+		"id":                  "int64.gt_lt",
+		"email":               "string.email",
+		"tags[0]":             "string.min_len",
+		"contact":             "required",
+		"friends[0].nickname": "string.min_len",
+	}, got)
+
+	ok := dynamicpb.NewMessage(md)
+	require.NoError(t, protojson.Unmarshal([]byte(`{"id": "7", "email": "a@example.com", "tags": ["go"], "phone": "1"}`), ok))
+	assert.Empty(t, checker.Check(ok))
+
+	described := map[string]string{}
+	for _, c := range checker.Describe(md) {
+		described[c.Path] = string(c.Rules)
+	}
+	assert.Contains(t, described, "contact")
+	assert.Contains(t, described["nickname"], "minLen")
+	assert.NotContains(t, described, "friends[].id")
+}
+
+func TestConstraintCheckerWithoutRules(t *testing.T) {
+	md := (&descriptorpb.FileDescriptorProto{}).ProtoReflect().Descriptor()
+	m := dynamicpb.NewMessage(md)
+	assert.Empty(t, NewConstraintChecker(md).Check(m))
+	assert.Empty(t, NewConstraintChecker(md).Describe(md))
+}
+
+func TestConstraintCheckers(t *testing.T) {
+	md := newConstrainedMessage(t)
+	other := (&descriptorpb.FileDescriptorProto{}).ProtoReflect().Descriptor()
+	checkers := NewConstraintCheckers(1)
+
+	checker := checkers.Get(md)
+	assert.Same(t, checker, checkers.Get(md))
+	// checkers are shared by concurrent calls
+	m := dynamicpb.NewMessage(md)
+	require.NoError(t, protojson.Unmarshal([]byte(`{"id": "100"}`), m))
+	done := make(chan []*ConstraintViolation, 4)
+	for i := 0; i < cap(done); i++ {
+		go func() { done <- checker.Check(m) }()
+	}
+	for i := 0; i < cap(done); i++ {
+		assert.NotEmpty(t, <-done)
+	}
+
+	checkers.Get(other)
+	assert.NotSame(t, checker, checkers.Get(md))
+}
@@ -0,0 +1,48 @@
+package protohelper
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// DeprecatedFieldsSet returns the paths of every deprecated field populated
+// in the message, walking into nested messages, lists and maps.
+func DeprecatedFieldsSet(m protoreflect.Message) []string {
+	out := []string{}
+	walkDeprecatedFields(m, "", &out)
+	return out
+}
+
+func walkDeprecatedFields(m protoreflect.Message, prefix string, out *[]string) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		path := fd.JSONName()
+		if prefix != "" {
+			path = fmt.Sprintf("%s.%s", prefix, path)
+		}
+		if IsDeprecated(fd) {
+			*out = append(*out, path)
+		}
+		switch {
+		case fd.IsMap():
+			if fd.MapValue().Message() == nil {
+				return true
+			}
+			v.Map().Range(func(k protoreflect.MapKey, mv protoreflect.Value) bool {
+				walkDeprecatedFields(mv.Message(), fmt.Sprintf("%s[%v]", path, k.Interface()), out)
+				return true
+			})
+		case fd.IsList():
+			if fd.Message() == nil {
+				return true
+			}
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				walkDeprecatedFields(list.Get(i).Message(), fmt.Sprintf("%s[%d]", path, i), out)
+			}
+		case fd.Message() != nil:
+			walkDeprecatedFields(v.Message(), path, out)
+		}
+		return true
+	})
+}
@@ -0,0 +1,70 @@
+package protohelper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const deprecationTestProto = `
+name: "demo/accounts.proto"
+package: "demo"
+syntax: "proto3"
+message_type: {
+  name: "Account"
+  field: { name: "name" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "name" }
+  field: { name: "legacy_id" number: 2 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "legacyId" options: { deprecated: true } }
+  field: { name: "profile" number: 3 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".demo.Profile" json_name: "profile" }
+  field: { name: "history" number: 4 label: LABEL_REPEATED type: TYPE_MESSAGE type_name: ".demo.Profile" json_name: "history" }
+  field: { name: "profiles" number: 5 label: LABEL_REPEATED type: TYPE_MESSAGE type_name: ".demo.Account.ProfilesEntry" json_name: "profiles" }
+  field: { name: "email" number: 6 label: LABEL_OPTIONAL type: TYPE_STRING oneof_index: 0 json_name: "email" options: { deprecated: true } }
+  field: { name: "phone" number: 7 label: LABEL_OPTIONAL type: TYPE_STRING oneof_index: 0 json_name: "phone" }
+  field: { name: "old_profile" number: 8 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".demo.Profile" json_name: "oldProfile" options: { deprecated: true } }
+  nested_type: {
+    name: "ProfilesEntry"
+    field: { name: "key" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "key" }
+    field: { name: "value" number: 2 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".demo.Profile" json_name: "value" }
+    options: { map_entry: true }
+  }
+  oneof_decl: { name: "contact" }
+}
+message_type: {
+  name: "Profile"
+  field: { name: "nickname" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "nickname" options: { deprecated: true } }
+  field: { name: "bio" number: 2 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "bio" }
+}
+`
+
+func TestDeprecatedFieldsSet(t *testing.T) {
+	md := mustTestFile(t, &protoregistry.Files{}, deprecationTestProto, nil).Messages().ByName("Account")
+	fieldsSet := func(in string) []string {
+		m := dynamicpb.NewMessage(md)
+		require.NoError(t, protojson.Unmarshal([]byte(in), m))
+		return DeprecatedFieldsSet(m)
+	}
+
+	assert.Empty(t, fieldsSet(`{"name": "berrypost", "phone": "1", "profile": {"bio": "hi"}}`))
+	assert.ElementsMatch(t, []string{
+		"legacyId",
+		"email",
+		"profile.nickname",
+		"history[1].nickname",
+		"profiles[alice].nickname",
+		"oldProfile",
+		"oldProfile.nickname",
+	}, fieldsSet(`{
+		"name": "berrypost",
+		"legacyId": "42",
+		"email": "a@example.com",
+		"profile": {"nickname": "bp", "bio": "hi"},
+		"history": [{"bio": "first"}, {"nickname": "second"}],
+		"profiles": {"alice": {"nickname": "al"}, "bob": {"bio": "b"}},
+		"oldProfile": {"nickname": "old"}
+	}`))
+	// the other member of the oneof is not deprecated
+	assert.Empty(t, fieldsSet(`{"phone": "1", "history": [{"bio": "first"}], "profiles": {"bob": {}}}`))
+}
@@ -0,0 +1,39 @@
+package protohelper
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// LeadingComments returns the leading comments of the descriptor, if its file
+// carries source code info.
+func LeadingComments(d protoreflect.Descriptor) string {
+	file := d.ParentFile()
+	if file == nil {
+		return ""
+	}
+	loc := file.SourceLocations().ByDescriptor(d)
+	return strings.TrimSpace(loc.LeadingComments)
+}
+
+// IsDeprecated reports whether the descriptor is marked with the deprecated
+// option.
+func IsDeprecated(d protoreflect.Descriptor) bool {
+	type deprecatable interface {
+		GetDeprecated() bool
+	}
+	opts, ok := d.Options().(deprecatable)
+	return ok && opts.GetDeprecated()
+}
+
+// IdempotencyLevel returns the idempotency_level option of the method, like
+// NO_SIDE_EFFECTS, IDEMPOTENCY_UNKNOWN if it is not set.
+func IdempotencyLevel(md protoreflect.MethodDescriptor) string {
+	opts, ok := md.Options().(*descriptorpb.MethodOptions)
+	if !ok {
+		return descriptorpb.MethodOptions_IDEMPOTENCY_UNKNOWN.String()
+	}
+	return opts.GetIdempotencyLevel().String()
+}
@@ -1,6 +1,12 @@
 package protohelper
 
-import "fmt"
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/golang/protobuf/jsonpb"
+)
 
 type DummyMessage struct {
 	payload []byte
@@ -14,3 +20,38 @@ func (dm *DummyMessage) Unmarshal(in []byte) error {
 	dm.payload = append(dm.payload[:0], in...)
 	return nil
 }
+
+// Payload returns the raw wire format of the message.
+func (dm *DummyMessage) Payload() []byte { return dm.payload }
+
+// Fields decodes the payload without a schema.
+func (dm *DummyMessage) Fields() ([]*WireField, error) { return DecodeWire(dm.payload) }
+
+type dummyMessageJSON struct {
+	Raw    string       `json:"@raw"`
+	Fields []*WireField `json:"@fields"`
+	Error  string       `json:"@error,omitempty"`
+}
+
+// MarshalJSONPB renders the payload as base64 along with the decoded fields,
+// the same way whether it is a reply in raw mode or an unresolved Any.
+func (dm *DummyMessage) MarshalJSONPB(*jsonpb.Marshaler) ([]byte, error) {
+	out := &dummyMessageJSON{Raw: base64.StdEncoding.EncodeToString(dm.payload)}
+	fields, err := dm.Fields()
+	out.Fields = fields
+	if err != nil {
+		out.Error = err.Error()
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSONPB accepts an object keyed by field numbers, see
+// EncodeFieldNumberJSON.
+func (dm *DummyMessage) UnmarshalJSONPB(_ *jsonpb.Unmarshaler, in []byte) error {
+	payload, err := EncodeFieldNumberJSON(in)
+	if err != nil {
+		return err
+	}
+	dm.payload = payload
+	return nil
+}
@@ -0,0 +1,96 @@
+package protohelper
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// NewDynamicTypes registers every message, enum and extension declared in the
+// file and its transitive imports as dynamic types.
+func NewDynamicTypes(fd protoreflect.FileDescriptor) *protoregistry.Types {
+	types := &protoregistry.Types{}
+	AddDynamicTypes(types, fd)
+	return types
+}
+
+// AddDynamicTypes adds the types of the file and its transitive imports to
+// the registry, types which are already registered are kept.
+func AddDynamicTypes(types *protoregistry.Types, fd protoreflect.FileDescriptor) {
+	seen := map[string]struct{}{}
+	var walkFile func(protoreflect.FileDescriptor)
+	var walkMessages func(protoreflect.MessageDescriptors)
+	walkEnums := func(enums protoreflect.EnumDescriptors) {
+		for i := 0; i < enums.Len(); i++ {
+			_ = types.RegisterEnum(dynamicpb.NewEnumType(enums.Get(i)))
+		}
+	}
+	walkExtensions := func(extensions protoreflect.ExtensionDescriptors) {
+		for i := 0; i < extensions.Len(); i++ {
+			_ = types.RegisterExtension(dynamicpb.NewExtensionType(extensions.Get(i)))
+		}
+	}
+	walkMessages = func(messages protoreflect.MessageDescriptors) {
+		for i := 0; i < messages.Len(); i++ {
+			md := messages.Get(i)
+			_ = types.RegisterMessage(dynamicpb.NewMessageType(md))
+			walkEnums(md.Enums())
+			walkExtensions(md.Extensions())
+			walkMessages(md.Messages())
+		}
+	}
+	walkFile = func(fd protoreflect.FileDescriptor) {
+		if _, ok := seen[fd.Path()]; ok {
+			return
+		}
+		seen[fd.Path()] = struct{}{}
+		walkEnums(fd.Enums())
+		walkExtensions(fd.Extensions())
+		walkMessages(fd.Messages())
+		imports := fd.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			walkFile(imports.Get(i).FileDescriptor)
+		}
+	}
+	walkFile(fd)
+}
+
+// ResolveOptions re-parses an options message with the given types, so custom
+// options stored as unknown fields become regular extension fields.
+func ResolveOptions(opts proto.Message, types *protoregistry.Types) (proto.Message, error) {
+	raw, err := proto.Marshal(opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal options")
+	}
+	out := opts.ProtoReflect().New().Interface()
+	if err := (proto.UnmarshalOptions{Resolver: types}).Unmarshal(raw, out); err != nil {
+		return nil, errors.Wrap(err, "unmarshal options")
+	}
+	return out, nil
+}
+
+// OptionsAsJSON renders the options of a descriptor, including custom options
+// declared in the imports of its file. It returns nil if no option is set.
+func OptionsAsJSON(d protoreflect.Descriptor, types *protoregistry.Types) (json.RawMessage, error) {
+	opts := d.Options()
+	if opts == nil || !opts.ProtoReflect().IsValid() {
+		return nil, nil
+	}
+	resolved, err := ResolveOptions(opts, types)
+	if err != nil {
+		return nil, err
+	}
+	if proto.Size(resolved) == 0 {
+		return nil, nil
+	}
+	out, err := protojson.MarshalOptions{Resolver: types}.Marshal(resolved)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal options as json")
+	}
+	return json.RawMessage(out), nil
+}
@@ -0,0 +1,34 @@
+package protohelper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+func TestOptionsAsJSON(t *testing.T) {
+	md := newConstrainedMessage(t)
+	types := NewDynamicTypes(md.ParentFile())
+
+	// custom options are stored as unknown fields until resolved
+	id := md.Fields().ByName("id")
+	assert.NotEmpty(t, id.Options().ProtoReflect().GetUnknown())
+	out, err := OptionsAsJSON(id, types)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"[validate.rules]": {"int64": {"gt": "0", "lt": "100"}}}`, string(out))
+
+	out, err = OptionsAsJSON(md.Oneofs().Get(0), types)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"[validate.required]": true}`, string(out))
+
+	// without the declaring file the option can not be named
+	out, err = OptionsAsJSON(id, &protoregistry.Types{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{}`, string(out))
+
+	out, err = OptionsAsJSON(md.Fields().ByName("friends"), types)
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
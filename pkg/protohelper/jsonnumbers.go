@@ -0,0 +1,265 @@
+package protohelper
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// jsonMember is a member of an object, objects are kept as ordered member
+// lists so that rewriting does not reorder the fields of the marshaler.
+type jsonMember struct {
+	key   string
+	value interface{}
+}
+
+type jsonObject []*jsonMember
+
+func decodeOrderedJSON(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := tok.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			obj := jsonObject{}
+			for dec.More() {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				value, err := decodeOrderedJSON(dec)
+				if err != nil {
+					return nil, err
+				}
+				obj = append(obj, &jsonMember{key: keyTok.(string), value: value})
+			}
+			_, err := dec.Token()
+			return obj, err
+		case '[':
+			list := []interface{}{}
+			for dec.More() {
+				value, err := decodeOrderedJSON(dec)
+				if err != nil {
+					return nil, err
+				}
+				list = append(list, value)
+			}
+			_, err := dec.Token()
+			return list, err
+		}
+		return nil, errors.Errorf("unexpected delimiter %q", t)
+	default:
+		return tok, nil
+	}
+}
+
+func encodeOrderedJSON(buf *bytes.Buffer, in interface{}) error {
+	switch v := in.(type) {
+	case jsonObject:
+		buf.WriteByte('{')
+		for i, m := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := encodeJSONScalar(buf, m.key); err != nil {
+				return err
+			}
+			buf.WriteByte(':')
+			if err := encodeOrderedJSON(buf, m.value); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := encodeOrderedJSON(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	default:
+		return encodeJSONScalar(buf, v)
+	}
+	return nil
+}
+
+// encodeJSONScalar writes a scalar without escaping HTML characters, which
+// would change strings that are left untouched otherwise.
+func encodeJSONScalar(buf *bytes.Buffer, in interface{}) error {
+	out := &bytes.Buffer{}
+	enc := json.NewEncoder(out)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(in); err != nil {
+		return err
+	}
+	buf.Write(bytes.TrimRight(out.Bytes(), "\n"))
+	return nil
+}
+
+// Int64AsNumbers rewrites the quoted 64-bit integers of a jsonpb document of
+// md as plain JSON numbers, member order and all other values are kept.
+func Int64AsNumbers(data []byte, md protoreflect.MessageDescriptor, resolver jsonpb.AnyResolver) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	doc, err := decodeOrderedJSON(dec)
+	if err != nil {
+		return nil, errors.Wrap(err, "decode json")
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after the top-level value")
+	}
+	w := &int64Rewriter{resolver: resolver}
+	doc = w.message(md, doc)
+
+	buf := &bytes.Buffer{}
+	if err := encodeOrderedJSON(buf, doc); err != nil {
+		return nil, errors.Wrap(err, "encode json")
+	}
+	return buf.Bytes(), nil
+}
+
+type int64Rewriter struct {
+	resolver jsonpb.AnyResolver
+}
+
+func isInt64Kind(kind protoreflect.Kind) bool {
+	switch kind {
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return true
+	default:
+		return false
+	}
+}
+
+func (w *int64Rewriter) number(in interface{}) interface{} {
+	if s, ok := in.(string); ok {
+		return json.Number(s)
+	}
+	return in
+}
+
+func (w *int64Rewriter) message(md protoreflect.MessageDescriptor, in interface{}) interface{} {
+	if md.FullName().Parent() == "google.protobuf" {
+		switch md.Name() {
+		case "Int64Value", "UInt64Value":
+			return w.number(in)
+		case "Any":
+			return w.any(in)
+		}
+	}
+	obj, ok := in.(jsonObject)
+	if !ok {
+		return in
+	}
+	fields := md.Fields()
+	for _, m := range obj {
+		fd := fields.ByJSONName(m.key)
+		if fd == nil {
+			fd = fields.ByName(protoreflect.Name(m.key))
+		}
+		if fd == nil || m.value == nil {
+			continue
+		}
+		m.value = w.field(fd, m.value)
+	}
+	return obj
+}
+
+func (w *int64Rewriter) field(fd protoreflect.FieldDescriptor, in interface{}) interface{} {
+	switch {
+	case fd.IsMap():
+		obj, ok := in.(jsonObject)
+		if !ok {
+			return in
+		}
+		for _, m := range obj {
+			m.value = w.singular(fd.MapValue(), m.value)
+		}
+		return obj
+	case fd.IsList():
+		list, ok := in.([]interface{})
+		if !ok {
+			return in
+		}
+		for i := range list {
+			list[i] = w.singular(fd, list[i])
+		}
+		return list
+	default:
+		return w.singular(fd, in)
+	}
+}
+
+func (w *int64Rewriter) singular(fd protoreflect.FieldDescriptor, in interface{}) interface{} {
+	switch {
+	case isInt64Kind(fd.Kind()):
+		return w.number(in)
+	case fd.Message() != nil:
+		return w.message(fd.Message(), in)
+	default:
+		return in
+	}
+}
+
+// any rewrites the embedded message if the type of the Any can be resolved.
+func (w *int64Rewriter) any(in interface{}) interface{} {
+	obj, ok := in.(jsonObject)
+	if !ok || w.resolver == nil {
+		return in
+	}
+	typeURL := ""
+	for _, m := range obj {
+		if m.key == "@type" {
+			typeURL, _ = m.value.(string)
+		}
+	}
+	if typeURL == "" {
+		return in
+	}
+	resolved, err := w.resolver.Resolve(typeURL)
+	if err != nil {
+		return in
+	}
+	if _, ok := resolved.(*DummyMessage); ok {
+		return in
+	}
+	md := proto.MessageReflect(resolved).Descriptor()
+	if embedsAsValue(md) {
+		for _, m := range obj {
+			if m.key == "value" {
+				m.value = w.message(md, m.value)
+			}
+		}
+		return obj
+	}
+	return w.message(md, obj)
+}
+
+// embedsAsValue reports whether jsonpb renders the message inside an Any as
+// {"@type": ..., "value": ...} instead of inlining its fields.
+func embedsAsValue(md protoreflect.MessageDescriptor) bool {
+	if md.FullName().Parent() != "google.protobuf" {
+		return false
+	}
+	switch md.Name() {
+	case "Empty", "Any", "BoolValue", "BytesValue", "StringValue",
+		"Int32Value", "UInt32Value", "FloatValue", "Int64Value", "UInt64Value", "DoubleValue",
+		"Duration", "Timestamp", "Struct", "Value", "ListValue":
+		return true
+	default:
+		return false
+	}
+}
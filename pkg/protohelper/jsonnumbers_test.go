@@ -0,0 +1,20 @@
+package protohelper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestInt64AsNumbers(t *testing.T) {
+	md := (&descriptorpb.UninterpretedOption{}).ProtoReflect().Descriptor()
+	in := `{"name":[{"namePart":"<a>","isExtension":false}],"positiveIntValue":"18446744073709551615","negativeIntValue":"-2","doubleValue":1.5,"stringValue":"YQ=="}`
+	out, err := Int64AsNumbers([]byte(in), md, nil)
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":[{"namePart":"<a>","isExtension":false}],"positiveIntValue":18446744073709551615,"negativeIntValue":-2,"doubleValue":1.5,"stringValue":"YQ=="}`, string(out))
+
+	_, err = Int64AsNumbers([]byte(`{} {}`), md, nil)
+	assert.Error(t, err)
+}
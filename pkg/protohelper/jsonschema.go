@@ -0,0 +1,323 @@
+package protohelper
+
+import (
+	"math"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	JSONSchemaDraft      = "https://json-schema.org/draft/2020-12/schema"
+	JSONSchemaDefsPrefix = "#/$defs/"
+)
+
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Deprecated           bool                   `json:"deprecated,omitempty"`
+	Default              interface{}            `json:"default,omitempty"`
+	Type                 interface{}            `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	ContentEncoding      string                 `json:"contentEncoding,omitempty"`
+	Enum                 []interface{}          `json:"enum,omitempty"`
+	Minimum              *float64               `json:"minimum,omitempty"`
+	Maximum              *float64               `json:"maximum,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties interface{}            `json:"additionalProperties,omitempty"`
+	PropertyNames        *JSONSchema            `json:"propertyNames,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	AnyOf                []*JSONSchema          `json:"anyOf,omitempty"`
+	AllOf                []*JSONSchema          `json:"allOf,omitempty"`
+	Not                  *JSONSchema            `json:"not,omitempty"`
+	Defs                 map[string]*JSONSchema `json:"$defs,omitempty"`
+}
+
+// JSONSchemaGenerator converts message descriptors into JSON Schema following
+// the protojson mapping. Every non well-known message is emitted once into
+// Defs and referenced by RefPrefix + full name, which keeps recursive types
+// finite.
+type JSONSchemaGenerator struct {
+	RefPrefix string
+	Defs      map[string]*JSONSchema
+}
+
+func NewJSONSchemaGenerator(refPrefix string) *JSONSchemaGenerator {
+	return &JSONSchemaGenerator{
+		RefPrefix: refPrefix,
+		Defs:      map[string]*JSONSchema{},
+	}
+}
+
+// JSONSchemaOf returns a standalone JSON Schema document for the message.
+func JSONSchemaOf(md protoreflect.MessageDescriptor) *JSONSchema {
+	g := NewJSONSchemaGenerator(JSONSchemaDefsPrefix)
+	root := g.MessageSchema(md)
+	root.Schema = JSONSchemaDraft
+	root.Defs = g.Defs
+	return root
+}
+
+func float64Ptr(in float64) *float64 {
+	return &in
+}
+
+// MessageSchema returns the schema of a message, as a reference for regular
+// messages and inline for well-known types.
+func (g *JSONSchemaGenerator) MessageSchema(md protoreflect.MessageDescriptor) *JSONSchema {
+	if wkt, ok := g.wellKnownSchema(md); ok {
+		return wkt
+	}
+	name := string(md.FullName())
+	if _, ok := g.Defs[name]; !ok {
+		def := &JSONSchema{}
+		// register before walking fields so that recursion stops here
+		g.Defs[name] = def
+		g.fillMessageDef(def, md)
+	}
+	return &JSONSchema{Ref: g.RefPrefix + name}
+}
+
+// fieldNames returns the names a field is accepted by, the JSON name and the
+// proto name if they differ.
+func fieldNames(fd protoreflect.FieldDescriptor) []string {
+	if fd.JSONName() == string(fd.Name()) {
+		return []string{fd.JSONName()}
+	}
+	return []string{fd.JSONName(), string(fd.Name())}
+}
+
+// exclusive returns a schema rejecting two of the names being present.
+func exclusive(description string, names []string) *JSONSchema {
+	conflicts := []*JSONSchema{}
+	for a := 0; a < len(names); a++ {
+		for b := a + 1; b < len(names); b++ {
+			conflicts = append(conflicts, &JSONSchema{Required: []string{names[a], names[b]}})
+		}
+	}
+	return &JSONSchema{Description: description, Not: &JSONSchema{AnyOf: conflicts}}
+}
+
+// fillMessageDef accepts the fields by their JSON and proto names, like
+// protojson does, at most one of them being present.
+func (g *JSONSchemaGenerator) fillMessageDef(def *JSONSchema, md protoreflect.MessageDescriptor) {
+	def.Title = string(md.FullName())
+	def.Description = LeadingComments(md)
+	def.Deprecated = IsDeprecated(md)
+	def.Type = "object"
+	def.Properties = map[string]*JSONSchema{}
+	def.AdditionalProperties = false
+
+	aliased := []string{}
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		f := fields.Get(i)
+		fs := nullable(g.FieldSchema(f))
+		fs.Description = LeadingComments(f)
+		fs.Deprecated = IsDeprecated(f)
+		names := fieldNames(f)
+		for _, name := range names {
+			def.Properties[name] = fs
+		}
+		if len(names) > 1 {
+			aliased = append(aliased, names...)
+			if f.ContainingOneof() == nil || f.ContainingOneof().IsSynthetic() {
+				def.AllOf = append(def.AllOf, exclusive("field "+string(f.Name()), names))
+			}
+		}
+		if f.Cardinality() != protoreflect.Required {
+			continue
+		}
+		if len(names) == 1 {
+			def.Required = append(def.Required, names[0])
+			continue
+		}
+		def.AllOf = append(def.AllOf, &JSONSchema{AnyOf: []*JSONSchema{
+			{Required: names[:1]},
+			{Required: names[1:]},
+		}})
+	}
+
+	oneofs := md.Oneofs()
+	for i := 0; i < oneofs.Len(); i++ {
+		od := oneofs.Get(i)
+		if od.IsSynthetic() {
+			continue
+		}
+		// at most one member of a oneof may be present, by either name
+		names := []string{}
+		members := od.Fields()
+		for j := 0; j < members.Len(); j++ {
+			names = append(names, fieldNames(members.Get(j))...)
+		}
+		if len(names) < 2 {
+			continue
+		}
+		def.AllOf = append(def.AllOf, exclusive("oneof "+string(od.Name()), names))
+	}
+}
+
+// nullable accepts null for a field, protojson reads it as the field not
+// being set.
+func nullable(in *JSONSchema) *JSONSchema {
+	switch t := in.Type.(type) {
+	case string:
+		if t == "null" {
+			return in
+		}
+		in.Type = []string{t, "null"}
+		if in.Enum != nil {
+			in.Enum = append(in.Enum, nil)
+		}
+		return in
+	case nil:
+		switch {
+		case in.Ref != "":
+			return &JSONSchema{AnyOf: []*JSONSchema{in, {Type: "null"}}}
+		case in.AnyOf != nil:
+			in.AnyOf = append(in.AnyOf, &JSONSchema{Type: "null"})
+		}
+	}
+	return in
+}
+
+// FieldSchema returns the schema of a field, including its list or map shape.
+func (g *JSONSchemaGenerator) FieldSchema(fd protoreflect.FieldDescriptor) *JSONSchema {
+	switch {
+	case fd.IsMap():
+		return &JSONSchema{
+			Type:                 "object",
+			PropertyNames:        mapKeySchema(fd.MapKey()),
+			AdditionalProperties: g.singularSchema(fd.MapValue()),
+		}
+	case fd.IsList():
+		return &JSONSchema{
+			Type:  "array",
+			Items: g.singularSchema(fd),
+		}
+	default:
+		return g.singularSchema(fd)
+	}
+}
+
+func mapKeySchema(fd protoreflect.FieldDescriptor) *JSONSchema {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return &JSONSchema{Enum: []interface{}{"true", "false"}}
+	case protoreflect.StringKind:
+		return nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return &JSONSchema{Pattern: "^[0-9]+$"}
+	default:
+		return &JSONSchema{Pattern: "^-?[0-9]+$"}
+	}
+}
+
+func (g *JSONSchemaGenerator) singularSchema(fd protoreflect.FieldDescriptor) *JSONSchema {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return g.MessageSchema(fd.Message())
+	case protoreflect.EnumKind:
+		return enumSchema(fd.Enum())
+	default:
+		return scalarSchema(fd.Kind())
+	}
+}
+
+func enumSchema(ed protoreflect.EnumDescriptor) *JSONSchema {
+	if ed.FullName() == "google.protobuf.NullValue" {
+		return &JSONSchema{Type: "null"}
+	}
+	out := &JSONSchema{
+		Title:       string(ed.FullName()),
+		Description: LeadingComments(ed),
+		Type:        "string",
+	}
+	values := ed.Values()
+	for i := 0; i < values.Len(); i++ {
+		out.Enum = append(out.Enum, string(values.Get(i).Name()))
+	}
+	return out
+}
+
+func scalarSchema(kind protoreflect.Kind) *JSONSchema {
+	switch kind {
+	case protoreflect.BoolKind:
+		return &JSONSchema{Type: "boolean"}
+	case protoreflect.StringKind:
+		return &JSONSchema{Type: "string"}
+	case protoreflect.BytesKind:
+		return &JSONSchema{Type: "string", ContentEncoding: "base64"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return &JSONSchema{
+			Type:    "integer",
+			Format:  "int32",
+			Minimum: float64Ptr(math.MinInt32),
+			Maximum: float64Ptr(math.MaxInt32),
+		}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return &JSONSchema{
+			Type:    "integer",
+			Format:  "uint32",
+			Minimum: float64Ptr(0),
+			Maximum: float64Ptr(math.MaxUint32),
+		}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return &JSONSchema{Type: "string", Format: "int64", Pattern: "^-?[0-9]+$"}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return &JSONSchema{Type: "string", Format: "uint64", Pattern: "^[0-9]+$"}
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return &JSONSchema{AnyOf: []*JSONSchema{
+			{Type: "number"},
+			{Type: "string", Enum: []interface{}{"NaN", "Infinity", "-Infinity"}},
+		}}
+	default:
+		return &JSONSchema{}
+	}
+}
+
+func (g *JSONSchemaGenerator) wellKnownSchema(md protoreflect.MessageDescriptor) (*JSONSchema, bool) {
+	name := md.FullName()
+	if name.Parent() != "google.protobuf" {
+		return nil, false
+	}
+	switch name.Name() {
+	case "Any":
+		return &JSONSchema{
+			Title: string(name),
+			Type:  "object",
+			Properties: map[string]*JSONSchema{
+				"@type": {Type: "string"},
+			},
+			Required: []string{"@type"},
+		}, true
+	case "Timestamp":
+		return &JSONSchema{Title: string(name), Type: "string", Format: "date-time"}, true
+	case "Duration":
+		return &JSONSchema{Title: string(name), Type: "string", Pattern: `^-?[0-9]+(\.[0-9]{1,9})?s$`}, true
+	case "FieldMask":
+		return &JSONSchema{Title: string(name), Type: "string"}, true
+	case "Struct":
+		return &JSONSchema{Title: string(name), Type: "object"}, true
+	case "Value":
+		return &JSONSchema{Title: string(name)}, true
+	case "ListValue":
+		return &JSONSchema{Title: string(name), Type: "array"}, true
+	case "Empty":
+		return &JSONSchema{Title: string(name), Type: "object", AdditionalProperties: false}, true
+	case "DoubleValue", "FloatValue", "Int64Value", "UInt64Value", "Int32Value", "UInt32Value",
+		"BoolValue", "StringValue", "BytesValue":
+		value := md.Fields().ByName("value")
+		if value == nil {
+			return nil, false
+		}
+		out := scalarSchema(value.Kind())
+		out.Title = string(name)
+		return out, true
+	default:
+		return nil, false
+	}
+}
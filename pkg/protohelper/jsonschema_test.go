@@ -0,0 +1,186 @@
+package protohelper
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestJSONSchemaOfRecursiveMessage(t *testing.T) {
+	schema := JSONSchemaOf((&descriptorpb.DescriptorProto{}).ProtoReflect().Descriptor())
+	assert.Equal(t, JSONSchemaDraft, schema.Schema)
+	assert.Equal(t, "#/$defs/google.protobuf.DescriptorProto", schema.Ref)
+
+	def := schema.Defs["google.protobuf.DescriptorProto"]
+	require.NotNil(t, def)
+	assert.Equal(t, false, def.AdditionalProperties)
+	assert.Equal(t, []string{"array", "null"}, def.Properties["nestedType"].Type)
+	assert.Same(t, def.Properties["nestedType"], def.Properties["nested_type"])
+	assert.Equal(t, "#/$defs/google.protobuf.DescriptorProto", def.Properties["nestedType"].Items.Ref)
+
+	field := schema.Defs["google.protobuf.FieldDescriptorProto"]
+	require.NotNil(t, field)
+	assert.Equal(t, []string{"integer", "null"}, field.Properties["number"].Type)
+	assert.Contains(t, field.Properties["type"].Enum, "TYPE_STRING")
+
+	option := schema.Defs["google.protobuf.UninterpretedOption"]
+	require.NotNil(t, option)
+	assert.Equal(t, []string{"string", "null"}, option.Properties["negativeIntValue"].Type)
+	assert.Equal(t, "^[0-9]+$", option.Properties["positiveIntValue"].Pattern)
+	assert.Equal(t, "base64", option.Properties["stringValue"].ContentEncoding)
+
+	_, err := json.Marshal(schema)
+	assert.NoError(t, err)
+}
+
+func TestJSONSchemaOfWellKnownTypes(t *testing.T) {
+	g := NewJSONSchemaGenerator(JSONSchemaDefsPrefix)
+	assert.Equal(t, "date-time", g.MessageSchema((&timestamppb.Timestamp{}).ProtoReflect().Descriptor()).Format)
+	assert.Equal(t, "object", g.MessageSchema((&structpb.Struct{}).ProtoReflect().Descriptor()).Type)
+	assert.Nil(t, g.MessageSchema((&structpb.Value{}).ProtoReflect().Descriptor()).Type)
+	assert.Empty(t, g.Defs)
+}
+
+// validateSchema checks a JSON value against the subset of JSON Schema the
+// generator emits.
+func validateSchema(root, schema *JSONSchema, value interface{}) error {
+	if schema.Ref != "" {
+		return validateSchema(root, root.Defs[strings.TrimPrefix(schema.Ref, JSONSchemaDefsPrefix)], value)
+	}
+	if schema.Type != nil {
+		types, ok := schema.Type.([]string)
+		if !ok {
+			types = []string{schema.Type.(string)}
+		}
+		matched := false
+		for _, t := range types {
+			matched = matched || jsonType(value) == t || (t == "number" && jsonType(value) == "integer")
+		}
+		if !matched {
+			return fmt.Errorf("%v is not %v", value, types)
+		}
+	}
+	if schema.Enum != nil {
+		found := false
+		for _, e := range schema.Enum {
+			found = found || e == value
+		}
+		if !found {
+			return fmt.Errorf("%v is not in %v", value, schema.Enum)
+		}
+	}
+	if schema.AnyOf != nil {
+		matched := false
+		for _, sub := range schema.AnyOf {
+			matched = matched || validateSchema(root, sub, value) == nil
+		}
+		if !matched {
+			return fmt.Errorf("%v matches none of anyOf", value)
+		}
+	}
+	for _, sub := range schema.AllOf {
+		if err := validateSchema(root, sub, value); err != nil {
+			return err
+		}
+	}
+	if schema.Not != nil && validateSchema(root, schema.Not, value) == nil {
+		return fmt.Errorf("%v matches not", value)
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, name := range schema.Required {
+			if _, ok := v[name]; !ok {
+				return fmt.Errorf("%s is required", name)
+			}
+		}
+		for name, field := range v {
+			if fs, ok := schema.Properties[name]; ok {
+				if err := validateSchema(root, fs, field); err != nil {
+					return fmt.Errorf("%s: %v", name, err)
+				}
+				continue
+			}
+			if additional, ok := schema.AdditionalProperties.(*JSONSchema); ok {
+				if err := validateSchema(root, additional, field); err != nil {
+					return fmt.Errorf("%s: %v", name, err)
+				}
+			} else if schema.AdditionalProperties == false {
+				return fmt.Errorf("%s is not allowed", name)
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if schema.Items != nil {
+				if err := validateSchema(root, schema.Items, item); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func jsonType(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if v == float64(int64(v)) {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+func TestJSONSchemaAcceptsProtoNames(t *testing.T) {
+	fdp := &descriptorpb.FileDescriptorProto{}
+	require.NoError(t, prototext.Unmarshal([]byte(templateTestProto), fdp))
+	fd, err := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	require.NoError(t, err)
+	md := fd.Messages().ByName("Request")
+	schema := JSONSchemaOf(md)
+
+	msg := dynamicpb.NewMessage(md)
+	require.NoError(t, protojson.Unmarshal([]byte(`{
+		"id": "1", "state": "STATE_ACTIVE", "createdAt": "2026-10-19T10:00:00Z", "tags": ["a"],
+		"attrs": {"k": {"id": "2"}}, "child": {"nick": "c"}, "name": "n"
+	}`), msg))
+	body, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"created_at"`)
+
+	valid := func(body string) error {
+		var value interface{}
+		require.NoError(t, json.Unmarshal([]byte(body), &value))
+		return validateSchema(schema, schema, value)
+	}
+	// an orig-name reply is a valid request again
+	require.NoError(t, valid(string(body)))
+	roundTrip := dynamicpb.NewMessage(md)
+	require.NoError(t, protojson.Unmarshal(body, roundTrip))
+
+	assert.NoError(t, valid(`{"createdAt": null, "child": null, "state": null, "tags": null}`))
+	assert.Error(t, valid(`{"createdAt": "2026-10-19T10:00:00Z", "created_at": "2026-10-19T10:00:00Z"}`))
+	assert.Error(t, valid(`{"name": "n", "nick": "c"}`))
+	assert.Error(t, valid(`{"createdAtt": null}`))
+}
@@ -0,0 +1,247 @@
+package protohelper
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const DefaultTemplateMaxDepth = 4
+
+type TemplateNote struct {
+	Path string `json:"path"`
+	Note string `json:"note"`
+}
+
+// templateBuilder fills a dynamic message with sample values: nested messages
+// are populated until MaxDepth or a cycle is reached, repeated and map fields
+// get one sample element and the first member of each oneof is chosen.
+type templateBuilder struct {
+	maxDepth int
+	notes    []*TemplateNote
+}
+
+// NewMessageTemplate returns a populated sample message for editing a request
+// body, and notes about choices that can not be expressed in JSON.
+func NewMessageTemplate(md protoreflect.MessageDescriptor, maxDepth int) (*dynamicpb.Message, []*TemplateNote) {
+	if maxDepth <= 0 {
+		maxDepth = DefaultTemplateMaxDepth
+	}
+	b := &templateBuilder{maxDepth: maxDepth, notes: []*TemplateNote{}}
+	out := dynamicpb.NewMessage(md)
+	b.fillMessage(out, "", 0, map[protoreflect.FullName]bool{md.FullName(): true})
+	return out, b.notes
+}
+
+func (b *templateBuilder) note(path, format string, args ...interface{}) {
+	if path == "" {
+		path = "."
+	}
+	b.notes = append(b.notes, &TemplateNote{Path: path, Note: fmt.Sprintf(format, args...)})
+}
+
+func joinTemplatePath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// canDescend reports whether a nested message may be populated at depth,
+// recording why not otherwise.
+func (b *templateBuilder) canDescend(md protoreflect.MessageDescriptor, path string, depth int, stack map[protoreflect.FullName]bool) bool {
+	if isTemplateWellKnown(md) {
+		return true
+	}
+	if stack[md.FullName()] {
+		b.note(path, "recursive type %s is left empty", md.FullName())
+		return false
+	}
+	if depth >= b.maxDepth {
+		b.note(path, "nesting deeper than %d levels is left empty", b.maxDepth)
+		return false
+	}
+	return true
+}
+
+func (b *templateBuilder) fillMessage(m protoreflect.Message, path string, depth int, stack map[protoreflect.FullName]bool) {
+	md := m.Descriptor()
+	if isTemplateWellKnown(md) {
+		fillWellKnown(m)
+		return
+	}
+
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		f := fields.Get(i)
+		fieldPath := joinTemplatePath(path, f.JSONName())
+
+		if od := f.ContainingOneof(); od != nil && !od.IsSynthetic() {
+			if od.Fields().Get(0) != f {
+				continue
+			}
+			if od.Fields().Len() > 1 {
+				alternatives := []string{}
+				for j := 0; j < od.Fields().Len(); j++ {
+					alternatives = append(alternatives, od.Fields().Get(j).JSONName())
+				}
+				b.note(fieldPath, "oneof %s, set exactly one of: %s", od.Name(), strings.Join(alternatives, ", "))
+			}
+		}
+
+		switch {
+		case f.IsMap():
+			b.fillMap(m, f, fieldPath, depth, stack)
+		case f.IsList():
+			b.fillList(m, f, fieldPath, depth, stack)
+		case f.Message() != nil:
+			if f.Message().FullName() == "google.protobuf.Any" {
+				b.note(fieldPath, "google.protobuf.Any needs an @type, e.g. {\"@type\": \"type.googleapis.com/<message>\"}")
+				continue
+			}
+			v := m.NewField(f)
+			b.fillNested(v.Message(), fieldPath, depth+1, stack)
+			m.Set(f, v)
+		default:
+			b.fillScalar(m, f, fieldPath)
+		}
+	}
+}
+
+// fillNested populates a nested message unless that would recurse or exceed
+// the depth limit, in which case it stays empty.
+func (b *templateBuilder) fillNested(m protoreflect.Message, path string, depth int, stack map[protoreflect.FullName]bool) {
+	md := m.Descriptor()
+	if !b.canDescend(md, path, depth, stack) {
+		return
+	}
+	b.fillMessage(m, path, depth, withTemplateStack(stack, md))
+}
+
+func withTemplateStack(stack map[protoreflect.FullName]bool, md protoreflect.MessageDescriptor) map[protoreflect.FullName]bool {
+	out := make(map[protoreflect.FullName]bool, len(stack)+1)
+	for k, v := range stack {
+		out[k] = v
+	}
+	out[md.FullName()] = true
+	return out
+}
+
+func (b *templateBuilder) fillScalar(m protoreflect.Message, f protoreflect.FieldDescriptor, path string) {
+	if f.Cardinality() == protoreflect.Required {
+		b.note(path, "required")
+	}
+	// proto2 and explicit presence fields are only rendered when set
+	switch {
+	case f.HasDefault():
+		m.Set(f, f.Default())
+	case f.Syntax() == protoreflect.Proto2 || f.ContainingOneof() != nil:
+		m.Set(f, zeroScalar(f))
+	}
+	if f.Enum() != nil {
+		values := f.Enum().Values()
+		names := make([]string, 0, values.Len())
+		for i := 0; i < values.Len(); i++ {
+			names = append(names, string(values.Get(i).Name()))
+		}
+		b.note(path, "enum %s: %s", f.Enum().FullName(), strings.Join(names, ", "))
+	}
+}
+
+func (b *templateBuilder) sampleElement(f protoreflect.FieldDescriptor, newElement func() protoreflect.Value, path string, depth int, stack map[protoreflect.FullName]bool) (protoreflect.Value, bool) {
+	if f.Message() == nil {
+		return zeroScalar(f), true
+	}
+	if f.Message().FullName() == "google.protobuf.Any" {
+		b.note(path, "google.protobuf.Any needs an @type, e.g. {\"@type\": \"type.googleapis.com/<message>\"}")
+		return protoreflect.Value{}, false
+	}
+	v := newElement()
+	b.fillNested(v.Message(), path, depth+1, stack)
+	return v, true
+}
+
+func (b *templateBuilder) fillList(m protoreflect.Message, f protoreflect.FieldDescriptor, path string, depth int, stack map[protoreflect.FullName]bool) {
+	list := m.NewField(f).List()
+	v, ok := b.sampleElement(f, list.NewElement, path+"[0]", depth, stack)
+	if !ok {
+		return
+	}
+	list.Append(v)
+	m.Set(f, protoreflect.ValueOfList(list))
+}
+
+func (b *templateBuilder) fillMap(m protoreflect.Message, f protoreflect.FieldDescriptor, path string, depth int, stack map[protoreflect.FullName]bool) {
+	mp := m.NewField(f).Map()
+	key := sampleMapKey(f.MapKey())
+	v, ok := b.sampleElement(f.MapValue(), mp.NewValue, fmt.Sprintf("%s[%v]", path, key.Interface()), depth, stack)
+	if !ok {
+		return
+	}
+	mp.Set(key, v)
+	m.Set(f, protoreflect.ValueOfMap(mp))
+}
+
+func sampleMapKey(fd protoreflect.FieldDescriptor) protoreflect.MapKey {
+	if fd.Kind() == protoreflect.StringKind {
+		return protoreflect.ValueOfString("key").MapKey()
+	}
+	return zeroScalar(fd).MapKey()
+}
+
+// zeroScalar returns the zero value of a scalar kind, repeated fields and map
+// entries have no default of their own.
+func zeroScalar(fd protoreflect.FieldDescriptor) protoreflect.Value {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return protoreflect.ValueOfBool(false)
+	case protoreflect.EnumKind:
+		if fd.Enum().Values().Len() > 0 {
+			return protoreflect.ValueOfEnum(fd.Enum().Values().Get(0).Number())
+		}
+		return protoreflect.ValueOfEnum(0)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return protoreflect.ValueOfInt32(0)
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return protoreflect.ValueOfInt64(0)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return protoreflect.ValueOfUint32(0)
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return protoreflect.ValueOfUint64(0)
+	case protoreflect.FloatKind:
+		return protoreflect.ValueOfFloat32(0)
+	case protoreflect.DoubleKind:
+		return protoreflect.ValueOfFloat64(0)
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte{})
+	default:
+		return protoreflect.ValueOfString("")
+	}
+}
+
+func isTemplateWellKnown(md protoreflect.MessageDescriptor) bool {
+	if md.FullName().Parent() != "google.protobuf" {
+		return false
+	}
+	switch md.Name() {
+	case "Timestamp", "Duration", "FieldMask", "Struct", "Value", "ListValue", "Empty",
+		"DoubleValue", "FloatValue", "Int64Value", "UInt64Value", "Int32Value", "UInt32Value",
+		"BoolValue", "StringValue", "BytesValue":
+		return true
+	default:
+		return false
+	}
+}
+
+// fillWellKnown makes sure the well-known type renders as a valid JSON value,
+// the zero values of all of them are valid except for an unset Value.
+func fillWellKnown(m protoreflect.Message) {
+	md := m.Descriptor()
+	if md.Name() != "Value" {
+		return
+	}
+	structValue := md.Fields().ByName("struct_value")
+	m.Set(structValue, m.NewField(structValue))
+}
@@ -0,0 +1,91 @@
+package protohelper
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const templateTestProto = `
+name: "demo/template.proto"
+package: "demo"
+syntax: "proto3"
+dependency: "google/protobuf/timestamp.proto"
+message_type: {
+  name: "Request"
+  field: { name: "id" number: 1 type: TYPE_INT64 label: LABEL_OPTIONAL json_name: "id" }
+  field: { name: "state" number: 2 type: TYPE_ENUM type_name: ".demo.State" label: LABEL_OPTIONAL json_name: "state" }
+  field: { name: "created_at" number: 3 type: TYPE_MESSAGE type_name: ".google.protobuf.Timestamp" label: LABEL_OPTIONAL json_name: "createdAt" }
+  field: { name: "tags" number: 4 type: TYPE_STRING label: LABEL_REPEATED json_name: "tags" }
+  field: { name: "attrs" number: 5 type: TYPE_MESSAGE type_name: ".demo.Request.AttrsEntry" label: LABEL_REPEATED json_name: "attrs" }
+  field: { name: "child" number: 6 type: TYPE_MESSAGE type_name: ".demo.Request" label: LABEL_OPTIONAL json_name: "child" }
+  field: { name: "name" number: 7 type: TYPE_STRING label: LABEL_OPTIONAL oneof_index: 0 json_name: "name" }
+  field: { name: "nick" number: 8 type: TYPE_STRING label: LABEL_OPTIONAL oneof_index: 0 json_name: "nick" }
+  nested_type: {
+    name: "AttrsEntry"
+    field: { name: "key" number: 1 type: TYPE_STRING label: LABEL_OPTIONAL json_name: "key" }
+    field: { name: "value" number: 2 type: TYPE_MESSAGE type_name: ".demo.Request" label: LABEL_OPTIONAL json_name: "value" }
+    options: { map_entry: true }
+  }
+  oneof_decl: { name: "display" }
+}
+enum_type: {
+  name: "State"
+  value: { name: "STATE_UNKNOWN" number: 0 }
+  value: { name: "STATE_ACTIVE" number: 1 }
+}
+`
+
+func TestNewMessageTemplate(t *testing.T) {
+	fdp := &descriptorpb.FileDescriptorProto{}
+	require.NoError(t, prototext.Unmarshal([]byte(templateTestProto), fdp))
+	fd, err := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	require.NoError(t, err)
+
+	md := fd.Messages().ByName("Request")
+	tmpl, notes := NewMessageTemplate(md, DefaultTemplateMaxDepth)
+	out, err := (&jsonpb.Marshaler{EmitDefaults: true}).MarshalToString(tmpl)
+	require.NoError(t, err)
+
+	body := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal([]byte(out), &body))
+	assert.Equal(t, "STATE_UNKNOWN", body["state"])
+	assert.Equal(t, "1970-01-01T00:00:00Z", body["createdAt"])
+	assert.Equal(t, []interface{}{""}, body["tags"])
+	assert.Contains(t, body["attrs"], "key")
+	assert.Equal(t, "", body["name"])
+	assert.NotContains(t, body, "nick")
+	assert.IsType(t, map[string]interface{}{}, body["child"])
+
+	paths := map[string]string{}
+	for _, n := range notes {
+		paths[n.Path] = n.Note
+	}
+	assert.Contains(t, paths["name"], "name, nick")
+	assert.Contains(t, paths["child"], "recursive type demo.Request")
+	assert.Contains(t, paths["state"], "STATE_ACTIVE")
+
+	// the template must be accepted as a request body as is
+	parsed := dynamicpb.NewMessage(md)
+	assert.NoError(t, jsonpb.UnmarshalString(out, parsed))
+}
+
+func TestNewMessageTemplateProto2(t *testing.T) {
+	md := (&descriptorpb.FieldDescriptorProto{}).ProtoReflect().Descriptor()
+	tmpl, notes := NewMessageTemplate(md, DefaultTemplateMaxDepth)
+	out, err := (&jsonpb.Marshaler{EmitDefaults: true}).MarshalToString(tmpl)
+	require.NoError(t, err)
+	assert.Contains(t, out, `"type":"TYPE_DOUBLE"`)
+	assert.Contains(t, out, `"number":0`)
+	assert.NotContains(t, out, "null")
+	assert.NotEmpty(t, notes)
+}
@@ -0,0 +1,450 @@
+package protohelper
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+type ValidationError struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+	// Unknown marks unknown fields, which are dropped in lenient mode.
+	Unknown bool `json:"unknown,omitempty"`
+}
+
+// ValidationErrors is returned when a JSON body does not fit the message.
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	out := make([]string, 0, len(e))
+	for _, v := range e {
+		out = append(out, fmt.Sprintf("%s: %s", v.Path, v.Message))
+	}
+	return fmt.Sprintf("Invalid request message: %s", strings.Join(out, "; "))
+}
+
+// UnknownFields returns the paths of the unknown fields.
+func (e ValidationErrors) UnknownFields() []string {
+	out := []string{}
+	for _, v := range e {
+		if v.Unknown {
+			out = append(out, v.Path)
+		}
+	}
+	return out
+}
+
+// Fatal drops the unknown field errors if unknown fields are allowed.
+func (e ValidationErrors) Fatal(allowUnknownFields bool) ValidationErrors {
+	if !allowUnknownFields {
+		return e
+	}
+	out := ValidationErrors{}
+	for _, v := range e {
+		if !v.Unknown {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// JSONValidator checks a JSON document against a message descriptor following
+// the protojson mapping, collecting every problem rather than the first one.
+type JSONValidator struct {
+	AnyResolver jsonpb.AnyResolver
+}
+
+// Validate returns the problems of the document, including unknown fields.
+func (v *JSONValidator) Validate(md protoreflect.MessageDescriptor, data []byte) ValidationErrors {
+	w := &jsonWalker{validator: v}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		w.fail(".", "malformed JSON: %s", err)
+		return w.errors
+	}
+	if dec.More() {
+		w.fail(".", "unexpected data after the top-level value")
+		return w.errors
+	}
+	if doc == nil {
+		w.fail(".", "expecting %s object, got null", md.FullName())
+		return w.errors
+	}
+	w.message(md, doc, "")
+	return w.errors
+}
+
+type jsonWalker struct {
+	validator *JSONValidator
+	errors    ValidationErrors
+}
+
+func (w *jsonWalker) fail(path, format string, args ...interface{}) {
+	w.errors = append(w.errors, &ValidationError{Path: rootPath(path), Message: fmt.Sprintf(format, args...)})
+}
+
+func rootPath(path string) string {
+	if path == "" {
+		return "."
+	}
+	return path
+}
+
+func jsonTypeOf(in interface{}) string {
+	switch in.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}, jsonObject:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", in)
+	}
+}
+
+func sortedKeys(in map[string]interface{}) []string {
+	keys := make([]string, 0, len(in))
+	for k := range in {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (w *jsonWalker) message(md protoreflect.MessageDescriptor, in interface{}, path string) {
+	if w.wellKnown(md, in, path) {
+		return
+	}
+	obj, ok := in.(map[string]interface{})
+	if !ok {
+		w.fail(path, "expecting %s object, got %s", md.FullName(), jsonTypeOf(in))
+		return
+	}
+
+	fields := md.Fields()
+	seen := map[protoreflect.FieldNumber]string{}
+	oneofs := map[protoreflect.FullName]string{}
+	for _, key := range sortedKeys(obj) {
+		value := obj[key]
+		fieldPath := joinTemplatePath(path, key)
+		fd := fields.ByJSONName(key)
+		if fd == nil {
+			fd = fields.ByName(protoreflect.Name(key))
+		}
+		if fd == nil {
+			w.errors = append(w.errors, &ValidationError{
+				Path:    fieldPath,
+				Message: fmt.Sprintf("unknown field %q in %s", key, md.FullName()),
+				Unknown: true,
+			})
+			continue
+		}
+		if prev, ok := seen[fd.Number()]; ok {
+			w.fail(fieldPath, "field %s is already set as %q", fd.Name(), prev)
+			continue
+		}
+		seen[fd.Number()] = key
+		if value == nil && !isNullValueField(fd) {
+			continue
+		}
+		if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+			if prev, ok := oneofs[od.FullName()]; ok {
+				w.fail(fieldPath, "oneof %s is already set by %q", od.Name(), prev)
+				continue
+			}
+			oneofs[od.FullName()] = key
+		}
+		w.field(fd, value, fieldPath)
+	}
+}
+
+func isNullValueField(fd protoreflect.FieldDescriptor) bool {
+	if fd.Message() != nil && fd.Message().FullName() == "google.protobuf.Value" {
+		return true
+	}
+	return fd.Enum() != nil && fd.Enum().FullName() == "google.protobuf.NullValue"
+}
+
+func (w *jsonWalker) field(fd protoreflect.FieldDescriptor, in interface{}, path string) {
+	switch {
+	case fd.IsMap():
+		obj, ok := in.(map[string]interface{})
+		if !ok {
+			w.fail(path, "expecting object for map field %s, got %s", fd.Name(), jsonTypeOf(in))
+			return
+		}
+		for _, key := range sortedKeys(obj) {
+			entryPath := fmt.Sprintf("%s[%s]", path, key)
+			w.mapKey(fd.MapKey(), key, entryPath)
+			w.singular(fd.MapValue(), obj[key], entryPath)
+		}
+	case fd.IsList():
+		list, ok := in.([]interface{})
+		if !ok {
+			w.fail(path, "expecting array for repeated field %s, got %s", fd.Name(), jsonTypeOf(in))
+			return
+		}
+		for i, item := range list {
+			w.singular(fd, item, fmt.Sprintf("%s[%d]", path, i))
+		}
+	default:
+		w.singular(fd, in, path)
+	}
+}
+
+func (w *jsonWalker) mapKey(fd protoreflect.FieldDescriptor, key, path string) {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+	case protoreflect.BoolKind:
+		if key != "true" && key != "false" {
+			w.fail(path, "invalid bool map key %q", key)
+		}
+	default:
+		w.integer(fd.Kind(), key, path)
+	}
+}
+
+func (w *jsonWalker) singular(fd protoreflect.FieldDescriptor, in interface{}, path string) {
+	if in == nil && !isNullValueField(fd) {
+		w.fail(path, "null is not allowed here")
+		return
+	}
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		w.message(fd.Message(), in, path)
+	case protoreflect.EnumKind:
+		w.enum(fd.Enum(), in, path)
+	default:
+		w.scalar(fd.Kind(), in, path)
+	}
+}
+
+func (w *jsonWalker) enum(ed protoreflect.EnumDescriptor, in interface{}, path string) {
+	if ed.FullName() == "google.protobuf.NullValue" {
+		if in != nil && in != "NULL_VALUE" {
+			w.fail(path, "expecting null, got %s", jsonTypeOf(in))
+		}
+		return
+	}
+	switch v := in.(type) {
+	case string:
+		if ed.Values().ByName(protoreflect.Name(v)) == nil {
+			names := []string{}
+			for i := 0; i < ed.Values().Len(); i++ {
+				names = append(names, string(ed.Values().Get(i).Name()))
+			}
+			w.fail(path, "unknown %s value %q, expecting one of: %s", ed.FullName(), v, strings.Join(names, ", "))
+		}
+	case json.Number:
+		w.integer(protoreflect.Int32Kind, v.String(), path)
+	default:
+		w.fail(path, "expecting %s name, got %s", ed.FullName(), jsonTypeOf(in))
+	}
+}
+
+func (w *jsonWalker) scalar(kind protoreflect.Kind, in interface{}, path string) {
+	switch kind {
+	case protoreflect.BoolKind:
+		if _, ok := in.(bool); !ok {
+			w.fail(path, "expecting boolean, got %s", jsonTypeOf(in))
+		}
+	case protoreflect.StringKind:
+		if _, ok := in.(string); !ok {
+			w.fail(path, "expecting string, got %s", jsonTypeOf(in))
+		}
+	case protoreflect.BytesKind:
+		s, ok := in.(string)
+		if !ok {
+			w.fail(path, "expecting base64 string, got %s", jsonTypeOf(in))
+			return
+		}
+		if _, err := base64.StdEncoding.DecodeString(s); err != nil {
+			w.fail(path, "invalid base64 string: %s", err)
+		}
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		var s string
+		switch v := in.(type) {
+		case json.Number:
+			s = v.String()
+		case string:
+			if v == "NaN" || v == "Infinity" || v == "-Infinity" {
+				return
+			}
+			s = v
+		default:
+			w.fail(path, "expecting number, got %s", jsonTypeOf(in))
+			return
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			w.fail(path, "invalid number %q", s)
+			return
+		}
+		if kind == protoreflect.FloatKind && math.Abs(f) > math.MaxFloat32 {
+			w.fail(path, "%s overflows float", s)
+		}
+	default:
+		switch v := in.(type) {
+		case json.Number:
+			w.integer(kind, v.String(), path)
+		case string:
+			w.integer(kind, v, path)
+		default:
+			w.fail(path, "expecting integer, got %s", jsonTypeOf(in))
+		}
+	}
+}
+
+func integerBits(kind protoreflect.Kind) (int, bool) {
+	switch kind {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return 32, true
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return 32, false
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return 64, false
+	default:
+		return 64, true
+	}
+}
+
+// integer checks a JSON number or string holding an integer, exponent and
+// fraction forms are rejected just like the unmarshaler does.
+func (w *jsonWalker) integer(kind protoreflect.Kind, s, path string) {
+	bits, signed := integerBits(kind)
+	var err error
+	if signed {
+		_, err = strconv.ParseInt(s, 10, bits)
+	} else {
+		_, err = strconv.ParseUint(s, 10, bits)
+	}
+	if err == nil {
+		return
+	}
+	if numErr, ok := err.(*strconv.NumError); ok && numErr.Err == strconv.ErrRange {
+		w.fail(path, "%s overflows %s", s, kind)
+		return
+	}
+	if !signed && strings.HasPrefix(s, "-") {
+		w.fail(path, "%s is negative, expecting %s", s, kind)
+		return
+	}
+	w.fail(path, "invalid %s %q", kind, s)
+}
+
+// wellKnown validates the special JSON forms of the well-known types, it
+// reports false if md is a regular message.
+func (w *jsonWalker) wellKnown(md protoreflect.MessageDescriptor, in interface{}, path string) bool {
+	name := md.FullName()
+	if name.Parent() != "google.protobuf" {
+		return false
+	}
+	expectString := func(what string, check func(string) error) {
+		s, ok := in.(string)
+		if !ok {
+			w.fail(path, "expecting %s string, got %s", what, jsonTypeOf(in))
+			return
+		}
+		if err := check(s); err != nil {
+			w.fail(path, "invalid %s %q: %s", what, s, err)
+		}
+	}
+	switch name.Name() {
+	case "Any":
+		w.any(in, path)
+	case "Timestamp":
+		expectString("RFC 3339 timestamp", func(s string) error {
+			_, err := time.Parse(time.RFC3339Nano, s)
+			return err
+		})
+	case "Duration":
+		expectString("duration", func(s string) error {
+			if !strings.HasSuffix(s, "s") {
+				return fmt.Errorf("missing unit suffix \"s\"")
+			}
+			_, err := strconv.ParseFloat(strings.TrimSuffix(s, "s"), 64)
+			return err
+		})
+	case "FieldMask":
+		expectString("field mask", func(string) error { return nil })
+	case "Struct":
+		if _, ok := in.(map[string]interface{}); !ok {
+			w.fail(path, "expecting object, got %s", jsonTypeOf(in))
+		}
+	case "ListValue":
+		if _, ok := in.([]interface{}); !ok {
+			w.fail(path, "expecting array, got %s", jsonTypeOf(in))
+		}
+	case "Value":
+	case "DoubleValue", "FloatValue", "Int64Value", "UInt64Value", "Int32Value", "UInt32Value",
+		"BoolValue", "StringValue", "BytesValue":
+		value := md.Fields().ByName("value")
+		if value == nil {
+			return false
+		}
+		w.scalar(value.Kind(), in, path)
+	default:
+		return false
+	}
+	return true
+}
+
+func (w *jsonWalker) any(in interface{}, path string) {
+	obj, ok := in.(map[string]interface{})
+	if !ok {
+		w.fail(path, "expecting google.protobuf.Any object, got %s", jsonTypeOf(in))
+		return
+	}
+	typePath := joinTemplatePath(path, "@type")
+	typeURL, ok := obj["@type"].(string)
+	if !ok {
+		w.fail(typePath, "missing or non-string @type in google.protobuf.Any")
+		return
+	}
+	pos := strings.LastIndex(typeURL, "/")
+	if pos < 0 || pos == len(typeURL)-1 || !protoreflect.FullName(typeURL[pos+1:]).IsValid() {
+		w.fail(typePath, "invalid type URL %q, expecting type.googleapis.com/<full message name>", typeURL)
+		return
+	}
+	if w.validator.AnyResolver == nil {
+		return
+	}
+	m, err := w.validator.AnyResolver.Resolve(typeURL)
+	if err != nil {
+		w.fail(typePath, "can not resolve type URL %q: %s", typeURL, err)
+		return
+	}
+	md := proto.MessageReflect(m).Descriptor()
+	if md.FullName().Parent() == "google.protobuf" {
+		if value, ok := obj["value"]; ok && w.wellKnown(md, value, joinTemplatePath(path, "value")) {
+			return
+		}
+	}
+	rest := make(map[string]interface{}, len(obj))
+	for k, v := range obj {
+		if k != "@type" {
+			rest[k] = v
+		}
+	}
+	w.message(md, rest, path)
+}
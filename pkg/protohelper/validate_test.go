@@ -0,0 +1,84 @@
+package protohelper
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/anypb"
+	_ "google.golang.org/protobuf/types/known/durationpb"
+)
+
+type globalTypesResolver struct{}
+
+func (globalTypesResolver) Resolve(typeURL string) (proto.Message, error) {
+	mt, err := protoregistry.GlobalTypes.FindMessageByURL(typeURL)
+	if err != nil {
+		return nil, err
+	}
+	return proto.MessageV1(mt.New().Interface()), nil
+}
+
+func violationsByPath(errs ValidationErrors) map[string]string {
+	out := map[string]string{}
+	for _, e := range errs {
+		out[e.Path] = e.Message
+	}
+	return out
+}
+
+func TestJSONValidatorReportsEveryProblem(t *testing.T) {
+	fdp := &descriptorpb.FileDescriptorProto{}
+	require.NoError(t, prototext.Unmarshal([]byte(templateTestProto), fdp))
+	fd, err := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	require.NoError(t, err)
+	md := fd.Messages().ByName("Request")
+
+	v := &JSONValidator{}
+	errs := v.Validate(md, []byte(`{
+		"id": "9223372036854775808",
+		"state": "STATE_GONE",
+		"createdAt": "yesterday",
+		"tags": ["a", 1],
+		"attrs": {"k": {"id": 1.5}},
+		"name": "a",
+		"nick": "b",
+		"unknownField": true
+	}`))
+	got := violationsByPath(errs)
+	assert.Contains(t, got["id"], "overflows")
+	assert.Contains(t, got["state"], "STATE_ACTIVE")
+	assert.Contains(t, got["createdAt"], "timestamp")
+	assert.Contains(t, got["tags[1]"], "expecting string")
+	assert.Contains(t, got["attrs[k].id"], "invalid")
+	assert.Contains(t, got["nick"], "oneof display")
+	assert.Contains(t, got["unknownField"], "unknown field")
+	assert.Len(t, errs, 7)
+
+	assert.Equal(t, []string{"unknownField"}, errs.UnknownFields())
+	assert.Len(t, errs.Fatal(true), 6)
+	assert.True(t, strings.HasPrefix(errs.Error(), "Invalid request message: "))
+
+	assert.Empty(t, v.Validate(md, []byte(`{"id": 1, "created_at": "2021-01-01T00:00:00Z", "child": {"state": 1}}`)))
+}
+
+func TestJSONValidatorAny(t *testing.T) {
+	md := (&anypb.Any{}).ProtoReflect().Descriptor()
+	v := &JSONValidator{AnyResolver: globalTypesResolver{}}
+
+	assert.Empty(t, v.Validate(md, []byte(`{"@type": "type.googleapis.com/google.protobuf.Duration", "value": "1.5s"}`)))
+	assert.Empty(t, v.Validate(md, []byte(`{"@type": "type.googleapis.com/google.protobuf.FieldDescriptorProto", "number": 1}`)))
+
+	got := violationsByPath(v.Validate(md, []byte(`{"@type": "not a url"}`)))
+	assert.Contains(t, got["@type"], "invalid type URL")
+	got = violationsByPath(v.Validate(md, []byte(`{"@type": "type.googleapis.com/demo.Missing"}`)))
+	assert.Contains(t, got["@type"], "can not resolve")
+	got = violationsByPath(v.Validate(md, []byte(`{"@type": "type.googleapis.com/google.protobuf.FieldDescriptorProto", "number": "one"}`)))
+	assert.Contains(t, got["number"], "invalid int32")
+}
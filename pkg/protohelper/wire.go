@@ -0,0 +1,392 @@
+package protohelper
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// WireField is a field decoded from the wire format without a schema. Value
+// is the most likely interpretation, Guesses holds the other plausible ones.
+// 64-bit integers are rendered as strings, the way jsonpb does.
+type WireField struct {
+	Number   protowire.Number       `json:"number"`
+	WireType string                 `json:"wire_type"`
+	Kind     string                 `json:"kind,omitempty"`
+	Value    interface{}            `json:"value,omitempty"`
+	Guesses  map[string]interface{} `json:"guesses,omitempty"`
+	Fields   []*WireField           `json:"fields,omitempty"`
+}
+
+const maxWireDepth = 64
+
+// DecodeWire decodes a message of unknown type into fields in the order they
+// appear on the wire.
+func DecodeWire(b []byte) ([]*WireField, error) {
+	fields, rest, err := decodeWire(b, 0, -1)
+	if err != nil {
+		return fields, err
+	}
+	if len(rest) > 0 {
+		return fields, errors.Errorf("unexpected end group at %d", len(b)-len(rest))
+	}
+	return fields, nil
+}
+
+// decodeWire consumes fields until b is exhausted or the end group of group,
+// the remaining bytes after the end group are returned.
+func decodeWire(b []byte, depth int, group protowire.Number) ([]*WireField, []byte, error) {
+	fields := []*WireField{}
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return fields, b, errors.Wrap(protowire.ParseError(n), "invalid tag")
+		}
+		b = b[n:]
+		field := &WireField{Number: num}
+		switch typ {
+		case protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			if n < 0 {
+				return fields, b, errors.Wrapf(protowire.ParseError(n), "field %d", num)
+			}
+			b = b[n:]
+			field.WireType, field.Value, field.Guesses = "varint", strconv.FormatUint(v, 10), varintGuesses(v)
+		case protowire.Fixed32Type:
+			v, n := protowire.ConsumeFixed32(b)
+			if n < 0 {
+				return fields, b, errors.Wrapf(protowire.ParseError(n), "field %d", num)
+			}
+			b = b[n:]
+			field.WireType, field.Value = "i32", v
+			field.Guesses = map[string]interface{}{"float": jsonFloat(float64(math.Float32frombits(v)))}
+			if int32(v) < 0 {
+				field.Guesses["int32"] = int32(v)
+			}
+		case protowire.Fixed64Type:
+			v, n := protowire.ConsumeFixed64(b)
+			if n < 0 {
+				return fields, b, errors.Wrapf(protowire.ParseError(n), "field %d", num)
+			}
+			b = b[n:]
+			field.WireType, field.Value = "i64", strconv.FormatUint(v, 10)
+			field.Guesses = map[string]interface{}{"double": jsonFloat(math.Float64frombits(v))}
+			if int64(v) < 0 {
+				field.Guesses["int64"] = strconv.FormatInt(int64(v), 10)
+			}
+		case protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return fields, b, errors.Wrapf(protowire.ParseError(n), "field %d", num)
+			}
+			b = b[n:]
+			field.WireType = "len"
+			guessBytes(field, v, depth)
+		case protowire.StartGroupType:
+			if depth >= maxWireDepth {
+				return fields, b, errors.Errorf("field %d: exceeds the max depth", num)
+			}
+			children, rest, err := decodeWire(b, depth+1, num)
+			if err != nil {
+				return fields, rest, err
+			}
+			b = rest
+			field.WireType, field.Kind, field.Fields = "group", "message", children
+		case protowire.EndGroupType:
+			if num != group {
+				return fields, b, errors.Errorf("unexpected end group %d", num)
+			}
+			return fields, b, nil
+		default:
+			return fields, b, errors.Errorf("field %d: invalid wire type %d", num, typ)
+		}
+		fields = append(fields, field)
+	}
+	if group >= 0 {
+		return fields, b, errors.Errorf("group %d is not terminated", group)
+	}
+	return fields, b, nil
+}
+
+func varintGuesses(v uint64) map[string]interface{} {
+	out := map[string]interface{}{}
+	if int64(v) < 0 {
+		out["int64"] = strconv.FormatInt(int64(v), 10)
+	}
+	if sint := protowire.DecodeZigZag(v); sint != int64(v) {
+		out["sint64"] = strconv.FormatInt(sint, 10)
+	}
+	if v <= 1 {
+		out["bool"] = v == 1
+	}
+	return out
+}
+
+// jsonFloat keeps NaN and infinities, which encoding/json refuses to encode.
+func jsonFloat(f float64) interface{} {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+	return f
+}
+
+// guessBytes prefers printable strings, then nested messages, then bytes.
+func guessBytes(field *WireField, v []byte, depth int) {
+	if isPrintable(v) {
+		field.Kind, field.Value = "string", string(v)
+		return
+	}
+	if len(v) > 0 && depth < maxWireDepth {
+		if children, err := decodeNested(v, depth+1); err == nil {
+			field.Kind, field.Fields = "message", children
+			return
+		}
+	}
+	field.Kind, field.Value = "bytes", base64.StdEncoding.EncodeToString(v)
+}
+
+func decodeNested(v []byte, depth int) ([]*WireField, error) {
+	children, rest, err := decodeWire(v, depth, -1)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, errors.New("unexpected end group")
+	}
+	return children, nil
+}
+
+func isPrintable(v []byte) bool {
+	if !utf8.Valid(v) {
+		return false
+	}
+	for _, r := range string(v) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// Protoscope renders the fields in the text syntax of protoscope.
+func Protoscope(fields []*WireField) string {
+	buf := &bytes.Buffer{}
+	writeProtoscope(buf, fields, "")
+	return buf.String()
+}
+
+func writeProtoscope(w io.Writer, fields []*WireField, indent string) {
+	for _, f := range fields {
+		fmt.Fprintf(w, "%s%d: ", indent, f.Number)
+		switch {
+		case f.WireType == "group":
+			fmt.Fprint(w, "!{\n")
+			writeProtoscope(w, f.Fields, indent+"  ")
+			fmt.Fprintf(w, "%s}\n", indent)
+		case f.Kind == "message":
+			fmt.Fprint(w, "{\n")
+			writeProtoscope(w, f.Fields, indent+"  ")
+			fmt.Fprintf(w, "%s}\n", indent)
+		case f.Kind == "string":
+			fmt.Fprintf(w, "{%s}\n", strconv.Quote(f.Value.(string)))
+		case f.Kind == "bytes":
+			raw, _ := base64.StdEncoding.DecodeString(f.Value.(string))
+			fmt.Fprintf(w, "{`%s`}\n", hex.EncodeToString(raw))
+		case f.WireType == "i32":
+			fmt.Fprintf(w, "%di32\n", f.Value)
+		case f.WireType == "i64":
+			fmt.Fprintf(w, "%si64\n", f.Value)
+		default:
+			fmt.Fprintf(w, "%s\n", f.Value)
+		}
+	}
+}
+
+// EncodeFieldNumberJSON encodes a JSON object keyed by field numbers into the
+// wire format, e.g. {"1": "abc", "2": {"1": 5}}. A key may carry the type of
+// the field after a colon, like "3:sint64" or "4:fixed32", otherwise integers
+// are varints, other numbers doubles, strings and bytes length-delimited and
+// objects nested messages. Arrays repeat the field. The "@raw" member is
+// appended as base64 encoded bytes.
+func EncodeFieldNumberJSON(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	doc, err := decodeOrderedJSON(dec)
+	if err != nil {
+		return nil, errors.Wrap(err, "decode json")
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after the top-level value")
+	}
+	obj, ok := doc.(jsonObject)
+	if !ok {
+		return nil, errors.Errorf("expecting an object keyed by field numbers, got %s", jsonTypeOf(doc))
+	}
+	return appendFieldNumberJSON(nil, obj, "")
+}
+
+func appendFieldNumberJSON(b []byte, obj jsonObject, path string) ([]byte, error) {
+	for _, m := range obj {
+		memberPath := joinTemplatePath(path, m.key)
+		switch m.key {
+		case "@type":
+			continue
+		case "@raw":
+			s, ok := m.value.(string)
+			if !ok {
+				return nil, errors.Errorf("%s: expecting base64 string", memberPath)
+			}
+			raw, err := base64.StdEncoding.DecodeString(s)
+			if err != nil {
+				return nil, errors.Wrapf(err, "%s", memberPath)
+			}
+			b = append(b, raw...)
+			continue
+		}
+		key, typ := m.key, ""
+		if pos := strings.IndexByte(key, ':'); pos >= 0 {
+			key, typ = key[:pos], key[pos+1:]
+		}
+		num, err := strconv.ParseUint(key, 10, 32)
+		if err != nil || !protowire.Number(num).IsValid() {
+			return nil, errors.Errorf("%s: invalid field number %q", memberPath, key)
+		}
+		values, ok := m.value.([]interface{})
+		if !ok {
+			values = []interface{}{m.value}
+		}
+		for _, v := range values {
+			if v == nil {
+				continue
+			}
+			if b, err = appendWireValue(b, protowire.Number(num), typ, v, memberPath); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return b, nil
+}
+
+func appendWireValue(b []byte, num protowire.Number, typ string, v interface{}, path string) ([]byte, error) {
+	switch v := v.(type) {
+	case jsonObject:
+		nested, err := appendFieldNumberJSON(nil, v, path)
+		if err != nil {
+			return nil, err
+		}
+		if typ == "group" {
+			b = protowire.AppendTag(b, num, protowire.StartGroupType)
+			b = append(b, nested...)
+			return protowire.AppendTag(b, num, protowire.EndGroupType), nil
+		}
+		b = protowire.AppendTag(b, num, protowire.BytesType)
+		return protowire.AppendBytes(b, nested), nil
+	case bool:
+		b = protowire.AppendTag(b, num, protowire.VarintType)
+		return protowire.AppendVarint(b, protowire.EncodeBool(v)), nil
+	case string:
+		raw := []byte(v)
+		if typ == "bytes" {
+			decoded, err := base64.StdEncoding.DecodeString(v)
+			if err != nil {
+				return nil, errors.Wrapf(err, "%s", path)
+			}
+			raw = decoded
+		}
+		b = protowire.AppendTag(b, num, protowire.BytesType)
+		return protowire.AppendBytes(b, raw), nil
+	case json.Number:
+		return appendWireNumber(b, num, typ, v, path)
+	default:
+		return nil, errors.Errorf("%s: unsupported value %s", path, jsonTypeOf(v))
+	}
+}
+
+func appendWireNumber(b []byte, num protowire.Number, typ string, v json.Number, path string) ([]byte, error) {
+	s := v.String()
+	isInt := !strings.ContainsAny(s, ".eE")
+	switch typ {
+	case "":
+		if isInt {
+			typ = "int64"
+			if !strings.HasPrefix(s, "-") {
+				typ = "uint64"
+			}
+		} else {
+			typ = "double"
+		}
+	}
+	var err error
+	switch typ {
+	case "int64", "int32", "enum":
+		var i int64
+		if i, err = strconv.ParseInt(s, 10, 64); err == nil {
+			b = protowire.AppendTag(b, num, protowire.VarintType)
+			b = protowire.AppendVarint(b, uint64(i))
+		}
+	case "uint64", "uint32", "varint":
+		var u uint64
+		if u, err = strconv.ParseUint(s, 10, 64); err == nil {
+			b = protowire.AppendTag(b, num, protowire.VarintType)
+			b = protowire.AppendVarint(b, u)
+		}
+	case "sint64", "sint32":
+		var i int64
+		if i, err = strconv.ParseInt(s, 10, 64); err == nil {
+			b = protowire.AppendTag(b, num, protowire.VarintType)
+			b = protowire.AppendVarint(b, protowire.EncodeZigZag(i))
+		}
+	case "fixed32", "sfixed32":
+		var i int64
+		if i, err = strconv.ParseInt(s, 10, 64); err == nil && (i < math.MinInt32 || i > math.MaxUint32) {
+			err = errors.New("out of range")
+		}
+		if err == nil {
+			b = protowire.AppendTag(b, num, protowire.Fixed32Type)
+			b = protowire.AppendFixed32(b, uint32(i))
+		}
+	case "fixed64", "sfixed64":
+		if strings.HasPrefix(s, "-") {
+			var i int64
+			if i, err = strconv.ParseInt(s, 10, 64); err == nil {
+				b = protowire.AppendTag(b, num, protowire.Fixed64Type)
+				b = protowire.AppendFixed64(b, uint64(i))
+			}
+		} else {
+			var u uint64
+			if u, err = strconv.ParseUint(s, 10, 64); err == nil {
+				b = protowire.AppendTag(b, num, protowire.Fixed64Type)
+				b = protowire.AppendFixed64(b, u)
+			}
+		}
+	case "float":
+		var f float64
+		if f, err = strconv.ParseFloat(s, 32); err == nil {
+			b = protowire.AppendTag(b, num, protowire.Fixed32Type)
+			b = protowire.AppendFixed32(b, math.Float32bits(float32(f)))
+		}
+	case "double":
+		var f float64
+		if f, err = strconv.ParseFloat(s, 64); err == nil {
+			b = protowire.AppendTag(b, num, protowire.Fixed64Type)
+			b = protowire.AppendFixed64(b, math.Float64bits(f))
+		}
+	default:
+		return nil, errors.Errorf("%s: unknown field type %q", path, typ)
+	}
+	if err != nil {
+		return nil, errors.Errorf("%s: invalid %s %s", path, typ, s)
+	}
+	return b, nil
+}
@@ -0,0 +1,67 @@
+package protohelper
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestEncodeFieldNumberJSON(t *testing.T) {
+	payload, err := EncodeFieldNumberJSON([]byte(`{"1": "abc", "2": {"1": 5}, "3:sint64": -2, "4:fixed32": [1, 2], "5": 1.5, "6": true, "7:group": {"1": 1}}`))
+	require.NoError(t, err)
+
+	fields, err := DecodeWire(payload)
+	require.NoError(t, err)
+	assert.Equal(t, `1: {"abc"}
+2: {
+  1: 5
+}
+3: 3
+4: 1i32
+4: 2i32
+5: 4609434218613702656i64
+6: 1
+7: !{
+  1: 1
+}
+`, Protoscope(fields))
+	assert.Equal(t, "-2", fields[2].Guesses["sint64"])
+	assert.Equal(t, 1.5, fields[5].Guesses["double"])
+	assert.Equal(t, true, fields[6].Guesses["bool"])
+
+	for _, in := range []string{`[]`, `{"a": 1}`, `{"0": 1}`, `{"1:int8": 1}`, `{"1:int32": 1.5}`} {
+		_, err := EncodeFieldNumberJSON([]byte(in))
+		assert.Error(t, err, in)
+	}
+}
+
+func TestDecodeWireBytes(t *testing.T) {
+	fields, err := DecodeWire([]byte{0x0a, 0x02, 0xff, 0xfe})
+	require.NoError(t, err)
+	assert.Equal(t, "bytes", fields[0].Kind)
+	assert.Equal(t, "1: {`fffe`}\n", Protoscope(fields))
+
+	_, err = DecodeWire([]byte{0x0a, 0x05, 0x01})
+	assert.Error(t, err)
+}
+
+func TestDummyMessageInUnresolvedAny(t *testing.T) {
+	payload, err := EncodeFieldNumberJSON([]byte(`{"1": "abc", "2": {"1": 5}}`))
+	require.NoError(t, err)
+	in := &anypb.Any{TypeUrl: "type.googleapis.com/unknown.Message", Value: payload}
+
+	marshaler := &jsonpb.Marshaler{AnyResolver: EmptyAnyResolver{}}
+	out, err := marshaler.MarshalToString(in)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(out, `{"@fields":[{"number":1,"wire_type":"len","kind":"string","value":"abc"}`), out)
+	assert.Contains(t, out, `"@type":"type.googleapis.com/unknown.Message"`)
+
+	unmarshaler := &jsonpb.Unmarshaler{AnyResolver: EmptyAnyResolver{}}
+	decoded := &anypb.Any{}
+	require.NoError(t, unmarshaler.Unmarshal(strings.NewReader(`{"@type": "type.googleapis.com/unknown.Message", "1": "abc", "2": {"1": 5}}`), decoded))
+	assert.Equal(t, payload, decoded.Value)
+}
@@ -0,0 +1,258 @@
+package proxy
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/realityone/berrypost/pkg/protohelper"
+	"google.golang.org/protobuf/encoding/prototext"
+	protov2 "google.golang.org/protobuf/proto"
+)
+
+// Media types of request and reply payloads. Base64 or hex wrapped protobuf is
+// ContentTypeProtobuf with an encoding=base64 or encoding=hex parameter.
+const (
+	ContentTypeJSON         = "application/json"
+	ContentTypeProtobuf     = "application/x-protobuf"
+	ContentTypeProtobufText = "text/x-protobuf"
+)
+
+// payloadCodec converts between HTTP payloads and messages obtained from the
+// proto store.
+type payloadCodec interface {
+	ContentType() string
+	Unmarshal(ctx *Context, data []byte, m proto.Message, resolver TypeResolver) error
+	Marshal(m proto.Message, opts MarshalOptions, resolver TypeResolver) ([]byte, error)
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) ContentType() string {
+	return ContentTypeJSON + "; charset=utf-8"
+}
+
+func (jsonCodec) Unmarshal(ctx *Context, data []byte, m proto.Message, resolver TypeResolver) error {
+	if dm, ok := m.(*protohelper.DummyMessage); ok {
+		if err := dm.UnmarshalJSONPB(nil, data); err != nil {
+			return errors.Errorf("Failed to encode field number json to request message: %+v", err)
+		}
+		return nil
+	}
+	lenient := IsLenientRequest(ctx)
+	validator := &protohelper.JSONValidator{AnyResolver: resolver}
+	problems := validator.Validate(proto.MessageReflect(m).Descriptor(), data)
+	if fatal := problems.Fatal(lenient); len(fatal) > 0 {
+		return fatal
+	}
+	for _, path := range problems.UnknownFields() {
+		ctx.addWarning("unknown field %s is dropped", path)
+	}
+
+	unmarshaler := jsonpb.Unmarshaler{
+		AllowUnknownFields: lenient,
+		AnyResolver:        protohelper.WrappedAnyResolver{AnyResolver: resolver},
+	}
+	if err := unmarshaler.Unmarshal(bytes.NewReader(data), m); err != nil {
+		return errors.Errorf("Failed to unmarshal json to request message: %+v", err)
+	}
+	return nil
+}
+
+func (jsonCodec) Marshal(m proto.Message, opts MarshalOptions, resolver TypeResolver) ([]byte, error) {
+	return opts.Marshal(m, protohelper.WrappedAnyResolver{AnyResolver: resolver})
+}
+
+// protobufCodec reads and writes the wire format, encoding is empty for
+// binary payloads or one of base64 and hex.
+type protobufCodec struct {
+	encoding string
+}
+
+func (c protobufCodec) ContentType() string {
+	if c.encoding != "" {
+		return ContentTypeProtobuf + "; encoding=" + c.encoding
+	}
+	return ContentTypeProtobuf
+}
+
+func decodeBase64Payload(data []byte) ([]byte, error) {
+	compact := strings.Join(strings.Fields(string(data)), "")
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.URLEncoding, base64.RawStdEncoding, base64.RawURLEncoding} {
+		if out, err := enc.DecodeString(compact); err == nil {
+			return out, nil
+		}
+	}
+	return nil, errors.New("Failed to decode base64 request body")
+}
+
+func decodeHexPayload(data []byte) ([]byte, error) {
+	compact := strings.Join(strings.Fields(string(data)), "")
+	out, err := hex.DecodeString(strings.TrimPrefix(compact, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to decode hex request body")
+	}
+	return out, nil
+}
+
+func (c protobufCodec) Unmarshal(ctx *Context, data []byte, m proto.Message, resolver TypeResolver) error {
+	var err error
+	switch c.encoding {
+	case "base64":
+		data, err = decodeBase64Payload(data)
+	case "hex":
+		data, err = decodeHexPayload(data)
+	}
+	if err != nil {
+		return err
+	}
+	if err := (protov2.UnmarshalOptions{Resolver: resolver}).Unmarshal(data, proto.MessageV2(m)); err != nil {
+		return errors.Errorf("Failed to unmarshal protobuf to request message: %+v", err)
+	}
+	return nil
+}
+
+func (c protobufCodec) Marshal(m proto.Message, _ MarshalOptions, _ TypeResolver) ([]byte, error) {
+	out, err := protov2.MarshalOptions{Deterministic: true}.Marshal(proto.MessageV2(m))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	switch c.encoding {
+	case "base64":
+		return []byte(base64.StdEncoding.EncodeToString(out)), nil
+	case "hex":
+		return []byte(hex.EncodeToString(out)), nil
+	}
+	return out, nil
+}
+
+// protoTextCodec reads and writes the protobuf text format. Requests of raw
+// calls have no schema to parse the text with and are rejected, their replies
+// are rendered in protoscope syntax instead. Unknown fields are emitted in
+// replies and dropped from lenient requests.
+type protoTextCodec struct{}
+
+func (protoTextCodec) ContentType() string {
+	return ContentTypeProtobufText + "; charset=utf-8"
+}
+
+func (protoTextCodec) Unmarshal(ctx *Context, data []byte, m proto.Message, resolver TypeResolver) error {
+	if _, ok := m.(*protohelper.DummyMessage); ok {
+		return errors.New("Text format requires a schema, use field number json or the wire format in raw mode")
+	}
+	m2 := proto.MessageV2(m)
+	unmarshaler := prototext.UnmarshalOptions{
+		DiscardUnknown: IsLenientRequest(ctx),
+		Resolver:       resolver,
+	}
+	if err := unmarshaler.Unmarshal(data, m2); err != nil {
+		return errors.Errorf("Failed to unmarshal text format to request message: %+v", err)
+	}
+	return nil
+}
+
+func (protoTextCodec) Marshal(m proto.Message, opts MarshalOptions, resolver TypeResolver) ([]byte, error) {
+	if dm, ok := m.(*protohelper.DummyMessage); ok {
+		fields, err := dm.Fields()
+		if err != nil {
+			return nil, err
+		}
+		return []byte(protohelper.Protoscope(fields)), nil
+	}
+	m2 := proto.MessageV2(m)
+	marshaler := prototext.MarshalOptions{
+		Multiline:   opts.Indent > 0,
+		Indent:      strings.Repeat(" ", opts.Indent),
+		EmitUnknown: true,
+		Resolver:    resolver,
+	}
+	out, err := marshaler.Marshal(m2)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return out, nil
+}
+
+// codecByMediaType returns the codec of a media type, ok is false for
+// unsupported types.
+func codecByMediaType(mediaType string, params map[string]string) (payloadCodec, bool) {
+	switch mediaType {
+	case "", ContentTypeJSON, "*/*", "application/*":
+		return jsonCodec{}, true
+	case ContentTypeProtobuf, "application/protobuf", "application/octet-stream":
+		switch encoding := strings.ToLower(params["encoding"]); encoding {
+		case "", "base64", "hex":
+			return protobufCodec{encoding: encoding}, true
+		}
+		return nil, false
+	case ContentTypeProtobufText, "application/x-protobuf-text":
+		return protoTextCodec{}, true
+	default:
+		return nil, false
+	}
+}
+
+// requestCodec picks the codec of the request body by its Content-Type. The
+// defaults of curl -d and fetch are kept as JSON, which used to be the only
+// supported payload.
+func requestCodec(contentType string) (payloadCodec, error) {
+	if contentType == "" {
+		return jsonCodec{}, nil
+	}
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid Content-Type: %q", contentType)
+	}
+	switch mediaType {
+	case "text/plain", "application/x-www-form-urlencoded":
+		return jsonCodec{}, nil
+	}
+	codec, ok := codecByMediaType(mediaType, params)
+	if !ok {
+		return nil, errors.Errorf("Unsupported Content-Type: %q, expecting %s, %s or %s", contentType, ContentTypeJSON, ContentTypeProtobuf, ContentTypeProtobufText)
+	}
+	return codec, nil
+}
+
+// replyCodec picks the codec of the reply by the Accept header, preferring
+// JSON if nothing acceptable is supported.
+func replyCodec(accept string) payloadCodec {
+	type candidate struct {
+		mediaType string
+		params    map[string]string
+		q         float64
+	}
+	candidates := []*candidate{}
+	for _, item := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(item))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+		candidates = append(candidates, &candidate{mediaType, params, q})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].q > candidates[j].q
+	})
+	for _, c := range candidates {
+		if c.q <= 0 {
+			continue
+		}
+		if codec, ok := codecByMediaType(c.mediaType, c.params); ok {
+			return codec
+		}
+	}
+	return jsonCodec{}
+}
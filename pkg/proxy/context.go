@@ -2,7 +2,11 @@ package proxy
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strconv"
+
+	"github.com/realityone/berrypost/pkg/metrics"
 )
 
 // Context is
@@ -13,6 +17,87 @@ type Context struct {
 	writer http.ResponseWriter
 
 	serviceMethod string
+	warnings      []string
+	// types resolves the Any fields and extensions of the request and reply.
+	types TypeResolver
+
+	// what the call went through, for the audit log
+	target      string
+	resolver    string
+	forwarded   map[string][]string
+	requestSize int
+	requestHash string
+	// methodKnown is set once the method is found in the protos
+	methodKnown bool
+}
+
+const proxyContextKey = "berrypost-proxy-context-key"
+
+// Value returns the Context itself for proxyContextKey, so that it can be
+// found from the contexts derived from it.
+func (c *Context) Value(key interface{}) interface{} {
+	if key == proxyContextKey {
+		return c
+	}
+	return c.Context.Value(key)
+}
+
+func fromContext(ctx context.Context) (*Context, bool) {
+	proxyCtx, ok := ctx.Value(proxyContextKey).(*Context)
+	return proxyCtx, ok
+}
+
+func (c *Context) addWarning(format string, args ...interface{}) {
+	c.warnings = append(c.warnings, fmt.Sprintf(format, args...))
+}
+
+// writeWarnings reports collected warnings as RFC 7234 Warning headers.
+func (c *Context) writeWarnings(dst http.Header) {
+	for _, w := range c.warnings {
+		dst.Add("Warning", fmt.Sprintf("299 berrypost %q", w))
+	}
+}
+
+// metricLabels labels the methods missing from the protos as unknown, the
+// service and method are given by clients.
+func (c *Context) metricLabels(service, method string) (string, string) {
+	if !c.methodKnown {
+		return metrics.Unknown, metrics.Unknown
+	}
+	return service, method
+}
+
+// IsLenientRequest reports whether unknown request fields should be dropped
+// instead of failing the call.
+func IsLenientRequest(ctx context.Context) bool {
+	proxyCtx, ok := ctx.(*Context)
+	if !ok {
+		return false
+	}
+	lenient, _ := strconv.ParseBool(proxyCtx.req.Header.Get("X-Berrypost-Lenient"))
+	return lenient
+}
+
+// IsSkipConstraintsRequest reports whether the request should be sent even if
+// it violates field constraints, to exercise validation on the server side.
+func IsSkipConstraintsRequest(ctx context.Context) bool {
+	proxyCtx, ok := ctx.(*Context)
+	if !ok {
+		return false
+	}
+	skip, _ := strconv.ParseBool(proxyCtx.req.Header.Get("X-Berrypost-Skip-Constraints"))
+	return skip
+}
+
+// IsRawRequest reports whether the call is made without a schema, the request
+// is sent as given and the reply is decoded from the wire format.
+func IsRawRequest(ctx context.Context) bool {
+	proxyCtx, ok := ctx.(*Context)
+	if !ok {
+		return false
+	}
+	raw, _ := strconv.ParseBool(proxyCtx.req.Header.Get("X-Berrypost-Raw"))
+	return raw
 }
 
 func GetUserDefinedTarget(ctx context.Context) (string, bool) {
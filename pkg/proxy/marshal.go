@@ -0,0 +1,125 @@
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/realityone/berrypost/pkg/protohelper"
+)
+
+const defaultMarshalIndent = 4
+
+// MarshalOptions controls how replies are rendered as JSON. Every option can
+// be overridden per request by a control header or a query parameter, e.g.
+// X-Berrypost-Emit-Defaults: true or ?emit_defaults=true.
+type MarshalOptions struct {
+	EmitDefaults  bool
+	OrigName      bool
+	EnumsAsInts   bool
+	Int64AsNumber bool
+	// Indent is the number of spaces per level, 0 for compact output.
+	Indent int
+}
+
+func DefaultMarshalOptions() MarshalOptions {
+	return MarshalOptions{Indent: defaultMarshalIndent}
+}
+
+type marshalOverride struct {
+	header string
+	query  string
+	apply  func(*MarshalOptions, string) error
+}
+
+func boolOverride(set func(*MarshalOptions, bool)) func(*MarshalOptions, string) error {
+	return func(o *MarshalOptions, v string) error {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return err
+		}
+		set(o, b)
+		return nil
+	}
+}
+
+var marshalOverrides = []marshalOverride{
+	{"X-Berrypost-Emit-Defaults", "emit_defaults", boolOverride(func(o *MarshalOptions, v bool) { o.EmitDefaults = v })},
+	{"X-Berrypost-Orig-Name", "orig_name", boolOverride(func(o *MarshalOptions, v bool) { o.OrigName = v })},
+	{"X-Berrypost-Enums-As-Ints", "enums_as_ints", boolOverride(func(o *MarshalOptions, v bool) { o.EnumsAsInts = v })},
+	{"X-Berrypost-Int64-As-Number", "int64_as_number", boolOverride(func(o *MarshalOptions, v bool) { o.Int64AsNumber = v })},
+	{"X-Berrypost-Indent", "indent", func(o *MarshalOptions, v string) error {
+		indent, err := ParseIndent(v)
+		if err != nil {
+			return err
+		}
+		o.Indent = indent
+		return nil
+	}},
+}
+
+// ParseIndent parses an indent option, compact or 0-16 spaces.
+func ParseIndent(v string) (int, error) {
+	if strings.EqualFold(v, "compact") {
+		return 0, nil
+	}
+	indent, err := strconv.Atoi(v)
+	if err != nil || indent < 0 || indent > 16 {
+		return 0, errors.Errorf("expecting compact or 0-16 spaces")
+	}
+	return indent, nil
+}
+
+// WithRequest returns the options overridden by the control headers and then
+// the query parameters of the request.
+func (o MarshalOptions) WithRequest(req *http.Request) (MarshalOptions, error) {
+	out := o
+	query := req.URL.Query()
+	for _, override := range marshalOverrides {
+		for _, v := range []string{req.Header.Get(override.header), query.Get(override.query)} {
+			if v == "" {
+				continue
+			}
+			if err := override.apply(&out, v); err != nil {
+				return o, errors.Wrapf(err, "invalid %s: %q", override.header, v)
+			}
+		}
+	}
+	return out, nil
+}
+
+// Marshal renders the message, the output is deterministic for a given
+// message and options.
+func (o MarshalOptions) Marshal(m proto.Message, resolver jsonpb.AnyResolver) ([]byte, error) {
+	marshaler := &jsonpb.Marshaler{
+		EmitDefaults: o.EmitDefaults,
+		OrigName:     o.OrigName,
+		EnumsAsInts:  o.EnumsAsInts,
+		AnyResolver:  resolver,
+	}
+	out := &bytes.Buffer{}
+	if err := marshaler.Marshal(out, m); err != nil {
+		return nil, err
+	}
+	data := out.Bytes()
+	if o.Int64AsNumber {
+		rewritten, err := protohelper.Int64AsNumbers(data, proto.MessageReflect(m).Descriptor(), resolver)
+		if err != nil {
+			return nil, err
+		}
+		data = rewritten
+	}
+	if o.Indent <= 0 {
+		return data, nil
+	}
+	indented := &bytes.Buffer{}
+	if err := json.Indent(indented, data, "", strings.Repeat(" ", o.Indent)); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return indented.Bytes(), nil
+}
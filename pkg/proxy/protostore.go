@@ -2,11 +2,10 @@ package proxy
 
 import (
 	"context"
-	"strings"
 
-	"github.com/golang/protobuf/jsonpb"
 	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 type RuntimeProtoStore interface {
@@ -14,6 +13,12 @@ type RuntimeProtoStore interface {
 	GetMessage(context.Context, string) (proto.Message, error)
 }
 
+// RuntimeMethodStore is implemented by proto stores which also know the
+// method descriptors, checks on method options rely on it.
+type RuntimeMethodStore interface {
+	GetMethodDescriptor(context.Context, string, string) (protoreflect.MethodDescriptor, error)
+}
+
 type defaultRuntimeProtoStore struct{}
 
 func (defaultRuntimeProtoStore) GetMethodMessage(context.Context, string, string) (proto.Message, proto.Message, error) {
@@ -23,55 +28,3 @@ func (defaultRuntimeProtoStore) GetMethodMessage(context.Context, string, string
 func (defaultRuntimeProtoStore) GetMessage(context.Context, string) (proto.Message, error) {
 	return nil, errors.New("unimpl")
 }
-
-type ctxedAnyResolver struct {
-	RuntimeProtoStore
-	ctx context.Context
-}
-
-func trimAnyTypePrefix(typeURL string) string {
-	pos := strings.Index(typeURL, "/")
-	if pos == -1 {
-		return typeURL
-	}
-	return typeURL[pos+1:]
-}
-
-func (a ctxedAnyResolver) resolveTo(typeURL string, dstMessage *proto.Message, dstError *error) <-chan struct{} {
-	doneSig := make(chan struct{})
-	go func() {
-		defer func() {
-			doneSig <- struct{}{}
-		}()
-		m, err := a.GetMessage(a.ctx, trimAnyTypePrefix(typeURL))
-		if err != nil {
-			*dstError = err
-			return
-		}
-		*dstMessage = m
-	}()
-	return doneSig
-}
-
-func (a ctxedAnyResolver) Resolve(typeURL string) (proto.Message, error) {
-	var (
-		dstMessage   proto.Message
-		resolveError error
-	)
-	select {
-	case <-a.resolveTo(typeURL, &dstMessage, &resolveError):
-		if resolveError != nil {
-			return nil, resolveError
-		}
-		return dstMessage, nil
-	case <-a.ctx.Done():
-		return nil, errors.WithStack(a.ctx.Err())
-	}
-}
-
-func AsContextedAnyResolver(ctx context.Context, in RuntimeProtoStore) jsonpb.AnyResolver {
-	return ctxedAnyResolver{
-		RuntimeProtoStore: in,
-		ctx:               ctx,
-	}
-}
@@ -2,24 +2,49 @@ package proxy
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"net"
 	"net/http"
+	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang/protobuf/jsonpb"
 	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
+	"github.com/realityone/berrypost/pkg/audit"
 	"github.com/realityone/berrypost/pkg/metadata"
+	"github.com/realityone/berrypost/pkg/metrics"
+	"github.com/realityone/berrypost/pkg/policy"
 	"github.com/realityone/berrypost/pkg/protohelper"
 	"github.com/realityone/berrypost/pkg/server"
+	"github.com/realityone/berrypost/pkg/server/auth"
 	"github.com/realityone/berrypost/pkg/server/contrib/errorhandler"
+	"github.com/realityone/berrypost/pkg/tracing"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	grpcmetadata "google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
 )
 
+// defaultConstraintCheckersSize bounds the request messages whose constraint
+// checkers are kept.
+const defaultConstraintCheckersSize = 256
+
 var (
 	_headerPrefix  = http.CanonicalHeaderKey("X-Berrypost-Md-")
 	_trailerPrefix = http.CanonicalHeaderKey("X-Berrypost-Md-Trailer-")
@@ -34,8 +59,16 @@ type ServerOpt func(*ProxyServer)
 // ProxyServer is
 // TODO: treat as a real gRPC server.
 type ProxyServer struct {
-	resolver   RuntimeServiceResolver
-	protoStore RuntimeProtoStore
+	resolver       RuntimeServiceResolver
+	protoStore     RuntimeProtoStore
+	types          *TypeCache
+	marshalOptions MarshalOptions
+	policy         *policy.Policy
+	readOnly       *policy.ReadOnly
+	targetGuard    *policy.TargetGuard
+	auditSink      audit.Sink
+	auditRedactor  *audit.Redactor
+	constraints    *protohelper.ConstraintCheckers
 }
 
 type clientID struct {
@@ -44,6 +77,7 @@ type clientID struct {
 }
 
 func (cs *clientSet) Close() error {
+	metrics.OpenConnections.Dec()
 	return cs.cc.Close()
 }
 
@@ -52,30 +86,111 @@ type metadataSet struct {
 	trailer grpcmetadata.MD
 }
 
-func (ps *ProxyServer) client(ctx *Context, service string) (*clientSet, error) {
-	userDefinedTarget, _ := GetUserDefinedTarget(ctx)
-	clientKey := clientID{service, userDefinedTarget}
-
-	toForward, err := extractIncommingGRPCMetadata(ctx.req.Header)
-	if err != nil {
-		return nil, errors.Wrap(err, "extract metadata")
-	}
-	dialCtx := grpcmetadata.NewOutgoingContext(ctx, toForward)
-
-	logrus.Debugf("Resolving service %+v to dial gRPC connection", clientKey)
-	target, err := ps.resolver.ResolveOnce(dialCtx, &ResolveOnceRequest{
+// resolveTarget returns the dial target of the service.
+func (ps *ProxyServer) resolveTarget(invokeCtx context.Context, ctx *Context, service string) (target string, err error) {
+	invokeCtx, span := tracing.Tracer().Start(invokeCtx, "resolve")
+	defer func() {
+		span.SetAttributes(attribute.String("berrypost.resolver", ctx.resolver), attribute.String("berrypost.target", target))
+		tracing.End(span, err)
+	}()
+
+	userDefinedTarget, ok := GetUserDefinedTarget(ctx)
+	if ok && ps.targetGuard != nil && ps.targetGuard.DisableUserDefinedTargets {
+		return "", blockedTargetError(&policy.TargetBlockedError{Target: userDefinedTarget, Reason: "user-defined targets are disabled"})
+	}
+	logrus.Debugf("Resolving service %+v to dial gRPC connection", clientID{service, userDefinedTarget})
+	ctx.resolver = ps.resolver.Name()
+	target, err = ps.resolver.ResolveOnce(invokeCtx, &ResolveOnceRequest{
 		ServiceFullyQualifiedName: service,
 		UserDefinedTarget:         userDefinedTarget,
 	})
+	if _, chained := ps.resolver.(chainedRuntimeResolver); !chained {
+		observeResolve(ps.resolver.Name(), err)
+	}
 	if err != nil {
-		return nil, err
+		return "", err
 	}
+	ctx.target = target
+	if ok {
+		if err = ps.targetGuard.Check(invokeCtx, net.DefaultResolver, target); err != nil {
+			return "", blockedTargetError(err)
+		}
+	}
+	return target, nil
+}
+
+// guardedDialer dials the target and checks the address it connects to, the
+// resolved addresses may differ from the ones checked before dialing.
+func guardedDialer(guard *policy.TargetGuard, target string) func(context.Context, string) (net.Conn, error) {
+	return func(ctx context.Context, addr string) (net.Conn, error) {
+		dialer := &net.Dialer{
+			Control: func(_, address string, _ syscall.RawConn) error {
+				host, _, err := net.SplitHostPort(address)
+				if err != nil {
+					return err
+				}
+				return guard.CheckIP(target, net.ParseIP(host))
+			},
+		}
+		conn, err := dialer.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return nil, unwrapBlocked(err)
+		}
+		if tcpAddr, ok := conn.RemoteAddr().(*net.TCPAddr); ok {
+			if err := guard.CheckIP(target, tcpAddr.IP); err != nil {
+				conn.Close()
+				return nil, err
+			}
+		}
+		return conn, nil
+	}
+}
 
-	logrus.Debugf("Dial gRPC connection to service: %+v with target: %q", clientKey, target)
-	newCC, err := grpc.DialContext(dialCtx, target, grpc.WithBlock(), grpc.WithInsecure())
+// unwrapBlocked returns the guard error from a dial error, so that gRPC sees
+// it is not temporary.
+func unwrapBlocked(err error) error {
+	var blocked *policy.TargetBlockedError
+	if errors.As(err, &blocked) {
+		return blocked
+	}
+	return err
+}
+
+func blockedTargetError(err error) error {
+	var blocked *policy.TargetBlockedError
+	if !errors.As(err, &blocked) {
+		return err
+	}
+	logrus.Warnf("Blocked target: %+v", err)
+	st, stErr := status.New(codes.PermissionDenied, blocked.Error()).
+		WithDetails(&errdetails.ErrorInfo{
+			Reason:   "TARGET_BLOCKED",
+			Domain:   "berrypost",
+			Metadata: map[string]string{"target": blocked.Target},
+		})
+	if stErr != nil {
+		return errors.WithStack(stErr)
+	}
+	return st.Err()
+}
+
+func (ps *ProxyServer) client(ctx context.Context, service, target string, guard *policy.TargetGuard) (*clientSet, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "dial", trace.WithAttributes(attribute.String("berrypost.target", target)))
+	logrus.Debugf("Dial gRPC connection to service: %q with target: %q", service, target)
+	opts := []grpc.DialOption{grpc.WithBlock(), grpc.WithInsecure()}
+	if guard != nil {
+		opts = append(opts, grpc.WithContextDialer(guardedDialer(guard, target)), grpc.FailOnNonTempDialError(true))
+	}
+	start := time.Now()
+	newCC, err := grpc.DialContext(ctx, target, opts...)
 	if err != nil {
-		return nil, err
+		metrics.DialDuration.WithLabelValues("error").Observe(metrics.Since(start))
+		tracing.End(span, err)
+		return nil, blockedTargetError(err)
 	}
+	span.End()
+	metrics.DialDuration.WithLabelValues("ok").Observe(metrics.Since(start))
+	metrics.OpenConnections.Inc()
 
 	newCliSet := &clientSet{
 		cc: newCC,
@@ -107,37 +222,68 @@ func writeMetadataAlways(mdSet *metadataSet, dst http.Header) {
 }
 
 func (ps *ProxyServer) ServeHTTP(ctx *gin.Context) {
+	start := time.Now()
+	service, method := ctx.Param("service"), ctx.Param("method")
+	serviceMethod := fmt.Sprintf("/%s/%s", service, method)
+	spanCtx, span := tracing.Tracer().Start(tracing.Extract(ctx, ctx.Request.Header), "invoke "+serviceMethod,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(semconv.RPCSystemKey.String("grpc"), semconv.RPCServiceKey.String(service), semconv.RPCMethodKey.String(method)),
+	)
+	if traceID := tracing.TraceID(spanCtx); traceID != "" {
+		ctx.Header(tracing.TraceIDHeader, traceID)
+	}
 	invokeCtx := &Context{
-		Context: ctx,
-		req:     ctx.Request,
-		writer:  ctx.Writer,
+		Context:       spanCtx,
+		req:           ctx.Request,
+		writer:        ctx.Writer,
+		serviceMethod: serviceMethod,
 	}
-
-	service, method := ctx.Param("service"), ctx.Param("method")
-	invokeCtx.serviceMethod = fmt.Sprintf("/%s/%s", service, method)
 	logrus.Debugf("Received gRPC call from http: %q", invokeCtx.serviceMethod)
 
+	var err error
+	defer func() {
+		tracing.End(span, err)
+		ps.writeAudit(ctx, invokeCtx, start, err)
+		code := status.Code(err).String()
+		labelService, labelMethod := invokeCtx.metricLabels(service, method)
+		resolver := invokeCtx.resolver
+		if resolver == "" {
+			resolver = metrics.Unknown
+		}
+		metrics.Invocations.WithLabelValues(labelService, labelMethod, resolver, code).Inc()
+		metrics.InvokeDuration.WithLabelValues(labelService, labelMethod, code).Observe(metrics.Since(start))
+	}()
+
+	marshalOptions, err := ps.marshalOptions.WithRequest(ctx.Request)
+	if err != nil {
+		ctx.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
 	reply, mdSet, err := ps.Invoke(invokeCtx)
 	if mdSet != nil {
 		writeMetadataAlways(mdSet, ctx.Writer.Header())
 	}
+	invokeCtx.writeWarnings(ctx.Writer.Header())
 	if err != nil {
 		logrus.Errorf("Failed to invoke backend on method: %q: %+v", invokeCtx.serviceMethod, err)
-		ctx.AbortWithError(http.StatusBadRequest, err)
+		ginErr := ctx.AbortWithError(http.StatusBadRequest, err)
+		if meta := errorMeta(err); meta != nil {
+			ginErr.SetMeta(meta)
+		}
 		return
 	}
 
-	marshaler := &jsonpb.Marshaler{
-		AnyResolver: protohelper.WrappedAnyResolver{
-			AnyResolver: AsContextedAnyResolver(ctx, ps.protoStore),
-		},
-		Indent: "    ",
-	}
-	if err := marshaler.Marshal(ctx.Writer, reply); err != nil {
+	codec := replyCodec(ctx.GetHeader("Accept"))
+	_, marshalSpan := tracing.Tracer().Start(spanCtx, "marshal")
+	out, err := codec.Marshal(reply, marshalOptions, invokeCtx.types)
+	tracing.End(marshalSpan, err)
+	if err != nil {
 		logrus.Errorf("Failed to marshal reply on method: %q: %+v", invokeCtx.serviceMethod, err)
 		ctx.AbortWithError(http.StatusBadRequest, err)
 		return
 	}
+	ctx.Data(http.StatusOK, codec.ContentType(), out)
 }
 
 func decodeBinHeader(v string) ([]byte, error) {
@@ -215,53 +361,309 @@ func (ps *ProxyServer) Invoke(ctx *Context) (proto.Message, *metadataSet, error)
 	if err != nil {
 		return nil, nil, err
 	}
-
-	cli, err := ps.client(ctx, service)
+	codec, err := requestCodec(ctx.req.Header.Get("Content-Type"))
 	if err != nil {
 		return nil, nil, err
 	}
-	defer cli.Close()
+	body, err := ioutil.ReadAll(ctx.req.Body)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "read request body")
+	}
+	ctx.requestSize = len(body)
+	ctx.requestHash = fmt.Sprintf("%x", sha256.Sum256(body))
 
 	toForward, err := extractIncommingGRPCMetadata(ctx.req.Header)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "extract metadata")
 	}
+	ctx.forwarded = toForward
 	invokeCtx := grpcmetadata.NewOutgoingContext(ctx, toForward)
 	invokeCtx = ps.prepareBuiltinMetadata(invokeCtx)
 
-	req, reply, err := ps.protoStore.GetMethodMessage(invokeCtx, service, method)
+	target, err := ps.resolveTarget(invokeCtx, ctx, service)
 	if err != nil {
 		return nil, nil, err
 	}
-	logrus.DebugFn(func() []interface{} {
-		return []interface{}{
-			fmt.Sprintf(
-				"Succeeded to get message type from proto store, request: %+v, reply: %+v",
-				protohelper.AsEmptyMessageJSON(req),
-				protohelper.AsEmptyMessageJSON(reply),
-			),
+	md := ps.methodDescriptor(invokeCtx, service, method)
+	ctx.methodKnown = md != nil
+	idempotencyLevel := methodIdempotencyLevel(md)
+	if err := checkReadOnly(ctx, ps.readOnly, service, method, target, idempotencyLevel); err != nil {
+		return nil, nil, err
+	}
+	if err := ps.authorize(invokeCtx, ctx, service, method, target, idempotencyLevel); err != nil {
+		return nil, nil, err
+	}
+
+	raw := IsRawRequest(ctx)
+	var req, reply proto.Message
+	if raw {
+		req, reply = &protohelper.DummyMessage{}, &protohelper.DummyMessage{}
+		ctx.types = ps.types.Resolver(invokeCtx, ps.protoStore)
+	} else {
+		if req, reply, err = ps.methodMessages(invokeCtx, md, service, method); err != nil {
+			return nil, nil, err
 		}
-	})
+		ctx.methodKnown = true
+		logrus.DebugFn(func() []interface{} {
+			return []interface{}{
+				fmt.Sprintf(
+					"Succeeded to get message type from proto store, request: %+v, reply: %+v",
+					protohelper.AsEmptyMessageJSON(req),
+					protohelper.AsEmptyMessageJSON(reply),
+				),
+			}
+		})
+		ctx.types = ps.types.Resolver(invokeCtx, ps.protoStore,
+			proto.MessageReflect(req).Descriptor().ParentFile(),
+			proto.MessageReflect(reply).Descriptor().ParentFile(),
+		)
+	}
 
-	unmarshaler := jsonpb.Unmarshaler{
-		AnyResolver: protohelper.WrappedAnyResolver{
-			AnyResolver: AsContextedAnyResolver(invokeCtx, ps.protoStore),
-		},
+	_, unmarshalSpan := tracing.Tracer().Start(invokeCtx, "unmarshal")
+	err = codec.Unmarshal(ctx, body, req, ctx.types)
+	tracing.End(unmarshalSpan, err)
+	if err != nil {
+		return nil, nil, err
 	}
-	if err := unmarshaler.Unmarshal(ctx.req.Body, req); err != nil {
-		return nil, nil, errors.Errorf("Failed to unmarshal json to request message: %+v", err)
+	if !raw {
+		checkDeprecation(ctx, md, req)
+		if err := ps.checkConstraints(ctx, req); err != nil {
+			return nil, nil, err
+		}
 	}
 
+	// the request is decoded and checked before dialing, bad input does not
+	// cost a connection to the backend
+	var guard *policy.TargetGuard
+	if _, ok := GetUserDefinedTarget(ctx); ok {
+		guard = ps.targetGuard
+	}
+	cli, err := ps.client(invokeCtx, service, target, guard)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer cli.Close()
+
 	mdSet := &metadataSet{
 		header:  grpcmetadata.MD{},
 		trailer: grpcmetadata.MD{},
 	}
-	if err := cli.cc.Invoke(invokeCtx, ctx.serviceMethod, req, reply, grpc.Header(&mdSet.header), grpc.Trailer(&mdSet.trailer)); err != nil {
+	rpcCtx, rpcSpan := tracing.Tracer().Start(invokeCtx, ctx.serviceMethod[1:],
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.RPCSystemKey.String("grpc"), semconv.RPCServiceKey.String(service), semconv.RPCMethodKey.String(method)),
+	)
+	rpcStart := time.Now()
+	err = cli.cc.Invoke(tracing.Inject(rpcCtx), ctx.serviceMethod, req, reply, grpc.Header(&mdSet.header), grpc.Trailer(&mdSet.trailer))
+	labelService, labelMethod := ctx.metricLabels(service, method)
+	metrics.RPCDuration.WithLabelValues(labelService, labelMethod, status.Code(err).String()).Observe(metrics.Since(rpcStart))
+	rpcSpan.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(status.Code(err))))
+	tracing.End(rpcSpan, err)
+	if err != nil {
 		return nil, mdSet, err
 	}
 	return reply, mdSet, nil
 }
 
+// methodDescriptor looks up the method once for the checks on its options and
+// the messages of the call, nil if the store does not know it.
+func (ps *ProxyServer) methodDescriptor(invokeCtx context.Context, service, method string) protoreflect.MethodDescriptor {
+	methodStore, ok := ps.protoStore.(RuntimeMethodStore)
+	if !ok {
+		return nil
+	}
+	lookupCtx, lookupSpan := tracing.Tracer().Start(invokeCtx, "proto lookup")
+	defer metrics.ObserveLookup("proto_store", "get_method_descriptor", time.Now())
+	md, err := methodStore.GetMethodDescriptor(lookupCtx, service, method)
+	tracing.End(lookupSpan, err)
+	if err != nil {
+		logrus.Debugf("Failed to get method descriptor of %q: %+v", service+"/"+method, err)
+		return nil
+	}
+	return md
+}
+
+// methodMessages returns the request and reply of the method, from its
+// descriptor if it is known.
+func (ps *ProxyServer) methodMessages(invokeCtx context.Context, md protoreflect.MethodDescriptor, service, method string) (proto.Message, proto.Message, error) {
+	if md != nil {
+		return dynamicpb.NewMessage(md.Input()), dynamicpb.NewMessage(md.Output()), nil
+	}
+	lookupCtx, lookupSpan := tracing.Tracer().Start(invokeCtx, "proto lookup")
+	defer metrics.ObserveLookup("proto_store", "get_method_message", time.Now())
+	req, reply, err := ps.protoStore.GetMethodMessage(lookupCtx, service, method)
+	tracing.End(lookupSpan, err)
+	return req, reply, err
+}
+
+func checkDeprecation(ctx *Context, md protoreflect.MethodDescriptor, req proto.Message) {
+	if md != nil && protohelper.IsDeprecated(md) {
+		ctx.addWarning("method %s is deprecated", md.FullName())
+	}
+	for _, path := range protohelper.DeprecatedFieldsSet(proto.MessageReflect(req)) {
+		ctx.addWarning("field %s is deprecated", path)
+	}
+}
+
+// writeAudit records the invocation to the audit sink, failures are logged
+// only.
+func (ps *ProxyServer) writeAudit(ginCtx *gin.Context, ctx *Context, start time.Time, err error) {
+	if ps.auditSink == nil {
+		return
+	}
+	record := &audit.Record{
+		Time:          start,
+		ClientIP:      ginCtx.ClientIP(),
+		Method:        ctx.serviceMethod,
+		Target:        ctx.target,
+		Resolver:      ctx.resolver,
+		Metadata:      ps.auditRedactor.Redact(ctx.forwarded),
+		RequestSize:   ctx.requestSize,
+		RequestSHA256: ctx.requestHash,
+		HTTPStatus:    ginCtx.Writer.Status(),
+		GRPCCode:      status.Code(err).String(),
+		LatencyMillis: float64(time.Since(start).Microseconds()) / 1000,
+		TraceID:       tracing.TraceID(ctx),
+	}
+	if identity, ok := auth.FromContext(ctx); ok {
+		record.Subject = identity.Subject
+	}
+	if vs := ctx.forwarded[metadata.ProtoRevisionGRPCMetadataKey]; len(vs) > 0 {
+		record.ProtoRevision = vs[0]
+	}
+	if vs := ctx.forwarded[metadata.ProtoPathGRPCMetadataKey]; len(vs) > 0 {
+		record.ProtoPath = vs[0]
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	if err := ps.auditSink.Write(record); err != nil {
+		logrus.Errorf("Failed to write audit record of %q: %+v", ctx.serviceMethod, err)
+	}
+}
+
+// methodIdempotencyLevel returns the idempotency_level option of the method,
+// IDEMPOTENCY_UNKNOWN if the method is not known.
+func methodIdempotencyLevel(md protoreflect.MethodDescriptor) string {
+	if md == nil {
+		return descriptorpb.MethodOptions_IDEMPOTENCY_UNKNOWN.String()
+	}
+	return protohelper.IdempotencyLevel(md)
+}
+
+// checkReadOnly rejects methods with side effects on read-only targets.
+func checkReadOnly(ctx *Context, readOnly *policy.ReadOnly, service, method, target, idempotencyLevel string) error {
+	if !readOnly.AppliesTo(target) || readOnly.IsSafe(service, method, idempotencyLevel) {
+		return nil
+	}
+	logrus.Warnf("Read-only mode blocked %q on target %q", ctx.serviceMethod, target)
+	st, err := status.New(codes.PermissionDenied, fmt.Sprintf("%s may have side effects, target %s is read-only in berrypost", ctx.serviceMethod, target)).
+		WithDetails(&errdetails.ErrorInfo{
+			Reason:   "READ_ONLY",
+			Domain:   "berrypost",
+			Metadata: map[string]string{"idempotency_level": idempotencyLevel},
+		})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return st.Err()
+}
+
+// authorize evaluates the policy before dialing the target, denials are
+// reported like a backend rejecting the call.
+func (ps *ProxyServer) authorize(invokeCtx context.Context, ctx *Context, service, method, target, idempotencyLevel string) error {
+	if ps.policy == nil {
+		return nil
+	}
+	req := &policy.Request{
+		Service:          service,
+		Method:           method,
+		Target:           target,
+		IdempotencyLevel: idempotencyLevel,
+	}
+	if identity, ok := auth.FromContext(ctx); ok {
+		req.Subject, req.Groups = identity.Subject, identity.Groups
+	}
+	if meta, ok := metadata.FromContext(invokeCtx); ok {
+		req.Revision = meta.ProtoRevision
+	}
+
+	decision := ps.policy.Evaluate(req)
+	if decision.Effect != policy.Deny {
+		logrus.Debugf("Policy decision on %q for %q: %s", ctx.serviceMethod, req.Subject, decision)
+		return nil
+	}
+	if decision.DryRun {
+		logrus.Warnf("Policy would deny %q for %q on target %q: %s", ctx.serviceMethod, req.Subject, target, decision)
+		ctx.addWarning("policy rule %s would deny this call", decision.Rule)
+		return nil
+	}
+	logrus.Warnf("Policy denied %q for %q on target %q: %s", ctx.serviceMethod, req.Subject, target, decision)
+	st, err := status.New(codes.PermissionDenied, fmt.Sprintf("call to %s on %s is denied by berrypost policy", ctx.serviceMethod, target)).
+		WithDetails(&errdetails.ErrorInfo{
+			Reason:   "POLICY_DENIED",
+			Domain:   "berrypost",
+			Metadata: map[string]string{"rule": decision.Rule, "subject": req.Subject},
+		})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return st.Err()
+}
+
+// checkConstraints evaluates protovalidate and PGV rules of the request, the
+// violations are reported like a backend rejecting the request.
+func (ps *ProxyServer) checkConstraints(ctx *Context, req proto.Message) error {
+	m := proto.MessageReflect(req)
+	violations := ps.constraints.Get(m.Descriptor()).Check(m)
+	if len(violations) <= 0 {
+		return nil
+	}
+	if IsSkipConstraintsRequest(ctx) {
+		for _, v := range violations {
+			ctx.addWarning("constraint %s violated on %s: %s", v.Rule, v.Path, v.Message)
+		}
+		return nil
+	}
+	badRequest := &errdetails.BadRequest{}
+	for _, v := range violations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Path,
+			Description: fmt.Sprintf("%s: %s", v.Rule, v.Message),
+		})
+	}
+	st, err := status.New(codes.InvalidArgument, "request violates field constraints, rejected by berrypost").WithDetails(badRequest)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return st.Err()
+}
+
+// errorMeta returns the structured part of invoke errors, which is merged
+// into the error detail.
+func errorMeta(err error) gin.H {
+	var problems protohelper.ValidationErrors
+	if errors.As(err, &problems) {
+		return gin.H{"violations": problems}
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return nil
+	}
+	details := []json.RawMessage{}
+	for _, d := range st.Proto().GetDetails() {
+		out, err := protojson.Marshal(d)
+		if err != nil {
+			out = []byte(strconv.Quote(fmt.Sprintf("%s: %s", d.GetTypeUrl(), err)))
+		}
+		details = append(details, json.RawMessage(out))
+	}
+	return gin.H{
+		"grpc_code":    st.Code().String(),
+		"grpc_message": st.Message(),
+		"grpc_details": details,
+	}
+}
+
 func (p *ProxyServer) Name() string {
 	return "proxy-server"
 }
@@ -270,6 +672,35 @@ func (p *ProxyServer) Meta() map[string]string {
 	return nil
 }
 
+// Mandatory is true, berrypost is of no use without invoking.
+func (p *ProxyServer) Mandatory() bool {
+	return true
+}
+
+// Ready reports whether the resolver and the proto store are ready, if they
+// tell.
+func (p *ProxyServer) Ready(ctx context.Context) error {
+	if rc, ok := p.resolver.(server.ReadinessChecker); ok {
+		if err := rc.Ready(ctx); err != nil {
+			return errors.Wrap(err, "resolve")
+		}
+	}
+	if rc, ok := p.protoStore.(server.ReadinessChecker); ok {
+		if err := rc.Ready(ctx); err != nil {
+			return errors.Wrap(err, "proto store")
+		}
+	}
+	return nil
+}
+
+// Stop closes the audit sink, after the in-flight invocations are drained.
+func (p *ProxyServer) Stop(ctx context.Context) error {
+	if p.auditSink == nil {
+		return nil
+	}
+	return p.auditSink.Close()
+}
+
 func (p *ProxyServer) Setup(s *server.Server) error {
 	s.POST("/invoke/:service/:method", errorhandler.JSONErrorHandler(), p.ServeHTTP)
 	return nil
@@ -278,8 +709,11 @@ func (p *ProxyServer) Setup(s *server.Server) error {
 // New is
 func New(opts ...ServerOpt) *ProxyServer {
 	ps := &ProxyServer{
-		resolver:   &defaultRuntimeServiceResolver{},
-		protoStore: &defaultRuntimeProtoStore{},
+		resolver:       &defaultRuntimeServiceResolver{},
+		protoStore:     &defaultRuntimeProtoStore{},
+		marshalOptions: DefaultMarshalOptions(),
+		types:          NewTypeCache(defaultTypeCacheSize, defaultTypeCacheTTL),
+		constraints:    protohelper.NewConstraintCheckers(defaultConstraintCheckersSize),
 	}
 	for _, opt := range opts {
 		opt(ps)
@@ -311,3 +745,55 @@ func SetProtoStore(in RuntimeProtoStore) ServerOpt {
 		s.protoStore = in
 	}
 }
+
+// SetTypeCache bounds the types cached for Any and extension resolution to
+// size proto revisions and paths, each reloaded after ttl.
+func SetTypeCache(size int, ttl time.Duration) ServerOpt {
+	return func(s *ProxyServer) {
+		s.types = NewTypeCache(size, ttl)
+	}
+}
+
+// SetPolicy authorizes every invocation with the policy, before dialing.
+func SetPolicy(in *policy.Policy) ServerOpt {
+	return func(s *ProxyServer) {
+		s.policy = in
+	}
+}
+
+// SetReadOnly only allows methods without side effects on the read-only
+// targets.
+func SetReadOnly(in *policy.ReadOnly) ServerOpt {
+	return func(s *ProxyServer) {
+		s.readOnly = in
+	}
+}
+
+// SetTargetGuard restricts the targets given by users with
+// X-Berrypost-Target, after DNS resolution and on the connected peer.
+func SetTargetGuard(in *policy.TargetGuard) ServerOpt {
+	return func(s *ProxyServer) {
+		s.targetGuard = in
+	}
+}
+
+// SetAuditSink records every invocation to the sink.
+func SetAuditSink(in audit.Sink) ServerOpt {
+	return func(s *ProxyServer) {
+		s.auditSink = in
+	}
+}
+
+// SetAuditRedactor sets the metadata values kept in clear in the audit
+// records, all of them are redacted by default.
+func SetAuditRedactor(in *audit.Redactor) ServerOpt {
+	return func(s *ProxyServer) {
+		s.auditRedactor = in
+	}
+}
+
+func SetMarshalOptions(in MarshalOptions) ServerOpt {
+	return func(s *ProxyServer) {
+		s.marshalOptions = in
+	}
+}
@@ -1,9 +1,36 @@
 package proxy
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/gin-gonic/gin"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/realityone/berrypost/pkg/metadata"
+	"github.com/realityone/berrypost/pkg/metrics"
+	"github.com/realityone/berrypost/pkg/policy"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
 )
 
 func TestDecodeBinHeader(t *testing.T) {
@@ -11,3 +38,452 @@ func TestDecodeBinHeader(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "dangerousd", v)
 }
+
+func TestErrorMetaOfStatus(t *testing.T) {
+	st, err := status.New(codes.InvalidArgument, "bad").WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "email", Description: "string.email"}},
+	})
+	assert.NoError(t, err)
+
+	meta := errorMeta(st.Err())
+	assert.Equal(t, "InvalidArgument", meta["grpc_code"])
+	assert.Equal(t, "bad", meta["grpc_message"])
+	details := meta["grpc_details"].([]json.RawMessage)
+	assert.Len(t, details, 1)
+	assert.Contains(t, string(details[0]), `"@type":"type.googleapis.com/google.rpc.BadRequest"`)
+
+	assert.Nil(t, errorMeta(errors.New("plain error")))
+}
+
+func TestMarshalOptionsWithRequest(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/invoke/demo.Users/Get?indent=compact&orig_name=true", nil)
+	req.Header.Set("X-Berrypost-Emit-Defaults", "true")
+	req.Header.Set("X-Berrypost-Orig-Name", "false")
+	opts, err := DefaultMarshalOptions().WithRequest(req)
+	assert.NoError(t, err)
+	assert.Equal(t, MarshalOptions{EmitDefaults: true, OrigName: true, Indent: 0}, opts)
+
+	req.Header.Set("X-Berrypost-Int64-As-Number", "maybe")
+	_, err = DefaultMarshalOptions().WithRequest(req)
+	assert.Error(t, err)
+}
+
+func TestPayloadCodecNegotiation(t *testing.T) {
+	for contentType, expected := range map[string]payloadCodec{
+		"":                                  jsonCodec{},
+		"application/json; charset=utf-8":   jsonCodec{},
+		"application/x-www-form-urlencoded": jsonCodec{},
+		"application/x-protobuf":            protobufCodec{},
+		"application/x-protobuf; encoding=base64": protobufCodec{encoding: "base64"},
+		"application/x-protobuf; encoding=hex":    protobufCodec{encoding: "hex"},
+		"text/x-protobuf":                         protoTextCodec{},
+	} {
+		codec, err := requestCodec(contentType)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, codec, contentType)
+	}
+	_, err := requestCodec("image/png")
+	assert.Error(t, err)
+	_, err = requestCodec("application/x-protobuf; encoding=gzip")
+	assert.Error(t, err)
+
+	assert.Equal(t, jsonCodec{}, replyCodec(""))
+	assert.Equal(t, jsonCodec{}, replyCodec("text/html, */*;q=0.8"))
+	assert.Equal(t, protoTextCodec{}, replyCodec("application/json;q=0.5, text/x-protobuf"))
+	assert.Equal(t, protobufCodec{encoding: "base64"}, replyCodec("application/x-protobuf; encoding=base64"))
+}
+
+func TestPayloadCodecRoundTrip(t *testing.T) {
+	in := &errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "email", Description: "string.email"}},
+	}
+	ctx := &Context{req: httptest.NewRequest(http.MethodPost, "/", nil)}
+	for _, codec := range []payloadCodec{jsonCodec{}, protobufCodec{}, protobufCodec{encoding: "base64"}, protobufCodec{encoding: "hex"}, protoTextCodec{}} {
+		data, err := codec.Marshal(in, DefaultMarshalOptions(), nil)
+		assert.NoError(t, err)
+		out := &errdetails.BadRequest{}
+		assert.NoError(t, codec.Unmarshal(ctx, data, out, nil), codec.ContentType())
+		assert.Equal(t, "email", out.GetFieldViolations()[0].GetField(), codec.ContentType())
+	}
+}
+
+const typeCacheTestProto = `
+name: "demo/cached.proto"
+package: "demo"
+syntax: "proto2"
+message_type: {
+  name: "Cached"
+  field: { name: "id" number: 1 label: LABEL_OPTIONAL type: TYPE_INT64 }
+  extension_range: { start: 100 end: 200 }
+}
+extension: { name: "note" number: 100 label: LABEL_OPTIONAL type: TYPE_STRING extendee: ".demo.Cached" }
+`
+
+type countingProtoStore struct {
+	defaultRuntimeProtoStore
+	files   *protoregistry.Files
+	lookups int
+}
+
+func (s *countingProtoStore) FindDescriptor(_ context.Context, name string) (protoreflect.Descriptor, error) {
+	s.lookups++
+	return s.files.FindDescriptorByName(protoreflect.FullName(name))
+}
+
+func newCountingProtoStore(t *testing.T) *countingProtoStore {
+	fdp := &descriptorpb.FileDescriptorProto{}
+	require.NoError(t, prototext.Unmarshal([]byte(typeCacheTestProto), fdp))
+	fd, err := protodesc.NewFile(fdp, nil)
+	require.NoError(t, err)
+	files := &protoregistry.Files{}
+	require.NoError(t, files.RegisterFile(fd))
+	return &countingProtoStore{files: files}
+}
+
+func TestTypeCache(t *testing.T) {
+	store := newCountingProtoStore(t)
+	cache := NewTypeCache(1, time.Minute)
+	withRevision := func(revision string) context.Context {
+		return context.WithValue(context.Background(), metadata.ContextKey, metadata.Metadata{ProtoRevision: revision})
+	}
+
+	m, err := cache.Resolver(withRevision("v1"), store).Resolve("type.googleapis.com/demo.Cached")
+	require.NoError(t, err)
+	assert.IsType(t, &dynamicpb.Message{}, m)
+	_, err = cache.Resolver(withRevision("v1"), store).Resolve("type.googleapis.com/demo.Cached")
+	require.NoError(t, err)
+	assert.Equal(t, 1, store.lookups)
+
+	resolver := cache.Resolver(withRevision("v1"), store)
+	xt, err := resolver.FindExtensionByNumber("demo.Cached", 100)
+	require.NoError(t, err)
+	assert.Equal(t, protoreflect.FullName("demo.note"), xt.TypeDescriptor().FullName())
+	_, err = resolver.FindExtensionByNumber("demo.Cached", 101)
+	assert.True(t, errors.Is(err, protoregistry.NotFound))
+
+	// v2 evicts v1 from a cache of size 1.
+	_, err = cache.Resolver(withRevision("v2"), store).Resolve("type.googleapis.com/demo.Cached")
+	require.NoError(t, err)
+	_, err = cache.Resolver(withRevision("v1"), store).Resolve("type.googleapis.com/demo.Cached")
+	require.NoError(t, err)
+	assert.Equal(t, 3, store.lookups)
+
+	// types of the global registry are not looked up in the store
+	_, err = cache.Resolver(withRevision("v1"), store).Resolve("type.googleapis.com/google.protobuf.FileDescriptorProto")
+	require.NoError(t, err)
+	assert.Equal(t, 3, store.lookups)
+
+	// extensions are loaded by name
+	xt, err = cache.Resolver(withRevision("v4"), store).FindExtensionByName("demo.note")
+	require.NoError(t, err)
+	assert.Equal(t, protoreflect.Name("note"), xt.TypeDescriptor().Name())
+	assert.Equal(t, 4, store.lookups)
+
+	// cancelled lookups are not remembered, misses are until the scope expires
+	cancelled, cancel := context.WithCancel(withRevision("v1"))
+	cancel()
+	_, err = cache.Resolver(cancelled, store).Resolve("type.googleapis.com/demo.Missing")
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, 4, store.lookups)
+	for i := 0; i < 2; i++ {
+		_, err = cache.Resolver(withRevision("v1"), store).Resolve("type.googleapis.com/demo.Missing")
+		assert.True(t, errors.Is(err, protoregistry.NotFound))
+		_, err = cache.Resolver(withRevision("v1"), store).FindExtensionByName("demo.missing")
+		assert.Error(t, err)
+	}
+	assert.Equal(t, 6, store.lookups)
+}
+
+// flakyProtoStore fails the first lookups as an unavailable store would.
+type flakyProtoStore struct {
+	*countingProtoStore
+	failures int
+}
+
+func (s *flakyProtoStore) FindDescriptor(ctx context.Context, name string) (protoreflect.Descriptor, error) {
+	if s.failures > 0 {
+		s.failures--
+		s.lookups++
+		return nil, errors.New("proto store is unavailable")
+	}
+	return s.countingProtoStore.FindDescriptor(ctx, name)
+}
+
+func TestTypeCacheMisses(t *testing.T) {
+	store := &flakyProtoStore{countingProtoStore: newCountingProtoStore(t), failures: 1}
+	cache := NewTypeCache(1, time.Minute)
+	ctx := context.Background()
+
+	// failures of the store are not remembered as missing names
+	_, err := cache.Resolver(ctx, store).Resolve("type.googleapis.com/demo.Cached")
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, protoregistry.NotFound))
+	_, err = cache.Resolver(ctx, store).Resolve("type.googleapis.com/demo.Cached")
+	require.NoError(t, err)
+	assert.Equal(t, 2, store.lookups)
+
+	// only the latest missing names are remembered
+	for i := 0; i <= defaultTypeMissesSize; i++ {
+		_, err = cache.Resolver(ctx, store).Resolve(fmt.Sprintf("type.googleapis.com/demo.Missing%d", i))
+		assert.True(t, errors.Is(err, protoregistry.NotFound))
+	}
+	lookups := store.lookups
+	_, err = cache.Resolver(ctx, store).Resolve(fmt.Sprintf("type.googleapis.com/demo.Missing%d", defaultTypeMissesSize))
+	assert.True(t, errors.Is(err, protoregistry.NotFound))
+	assert.Equal(t, lookups, store.lookups)
+	_, err = cache.Resolver(ctx, store).Resolve("type.googleapis.com/demo.Missing0")
+	assert.True(t, errors.Is(err, protoregistry.NotFound))
+	assert.Equal(t, lookups+1, store.lookups)
+}
+
+func TestTypeCacheExpiry(t *testing.T) {
+	store := newCountingProtoStore(t)
+	cache := NewTypeCache(2, 20*time.Millisecond)
+	ctx := context.Background()
+
+	_, err := cache.Resolver(ctx, store).Resolve("type.googleapis.com/demo.Missing")
+	assert.Error(t, err)
+	_, err = cache.Resolver(ctx, store).Resolve("type.googleapis.com/demo.Cached")
+	require.NoError(t, err)
+	_, err = cache.Resolver(ctx, store).Resolve("type.googleapis.com/demo.Missing")
+	assert.Error(t, err)
+	assert.Equal(t, 2, store.lookups)
+
+	time.Sleep(40 * time.Millisecond)
+	_, err = cache.Resolver(ctx, store).Resolve("type.googleapis.com/demo.Cached")
+	require.NoError(t, err)
+	_, err = cache.Resolver(ctx, store).Resolve("type.googleapis.com/demo.Missing")
+	assert.Error(t, err)
+	assert.Equal(t, 4, store.lookups)
+}
+
+func TestGuardedDialer(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer lis.Close()
+	_, port, _ := net.SplitHostPort(lis.Addr().String())
+
+	allowed := &policy.TargetGuard{}
+	require.NoError(t, allowed.Compile())
+	conn, err := guardedDialer(allowed, "localhost")(context.Background(), lis.Addr().String())
+	require.NoError(t, err)
+	conn.Close()
+
+	// the host name passed the checks before dialing, the peer does not
+	denied := &policy.TargetGuard{DenyCIDRs: []string{"127.0.0.0/8", "::1/128"}}
+	require.NoError(t, denied.Compile())
+	_, err = guardedDialer(denied, "localhost:"+port)(context.Background(), "localhost:"+port)
+	var blocked *policy.TargetBlockedError
+	require.True(t, errors.As(err, &blocked))
+	assert.False(t, blocked.Temporary())
+
+	st, ok := status.FromError(blockedTargetError(err))
+	require.True(t, ok)
+	assert.Equal(t, codes.PermissionDenied, st.Code())
+}
+
+type failingResolver struct{}
+
+func (failingResolver) ResolveOnce(context.Context, *ResolveOnceRequest) (string, error) {
+	return "", errors.New("registry is down")
+}
+
+func (failingResolver) Name() string {
+	return "failing-resolver"
+}
+
+func TestResolverMetrics(t *testing.T) {
+	count := func(resolver, result string) float64 {
+		return testutil.ToFloat64(metrics.ResolverResults.WithLabelValues(resolver, result))
+	}
+	skipped, failed := count("default-resolver", "skipped"), count("failing-resolver", "error")
+	resolved := count("default-resolver", "resolved")
+
+	r := ChainDefaultResolver(failingResolver{})
+	_, err := r.ResolveOnce(context.Background(), &ResolveOnceRequest{ServiceFullyQualifiedName: "demo.Greeter"})
+	assert.Error(t, err)
+	_, err = r.ResolveOnce(context.Background(), &ResolveOnceRequest{UserDefinedTarget: "tcp://127.0.0.1:9000"})
+	require.NoError(t, err)
+
+	assert.Equal(t, skipped+1, count("default-resolver", "skipped"))
+	assert.Equal(t, failed+1, count("failing-resolver", "error"))
+	assert.Equal(t, resolved+1, count("default-resolver", "resolved"))
+}
+
+// startBackend serves gRPC without any service, every call is unimplemented.
+func startBackend(t *testing.T) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	return "tcp://" + lis.Addr().String()
+}
+
+func TestInvocationMetricsLabels(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ps := New()
+	backend := startBackend(t)
+	invoke := func(service string) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest(http.MethodPost, "/invoke/"+service+"/Get", nil)
+		ctx.Request.Header.Set("X-Berrypost-Target", backend)
+		ctx.Params = gin.Params{{Key: "service", Value: service}, {Key: "method", Value: "Get"}}
+		ps.ServeHTTP(ctx)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	}
+
+	invoke("demo.Missing")
+	series, durations := testutil.CollectAndCount(metrics.Invocations), testutil.CollectAndCount(metrics.InvokeDuration)
+	unknown := testutil.ToFloat64(metrics.Invocations.WithLabelValues(metrics.Unknown, metrics.Unknown, "default-resolver", "Unknown"))
+	invoke("demo.Missing2")
+	invoke("attacker.Chosen")
+	assert.Equal(t, series, testutil.CollectAndCount(metrics.Invocations))
+	assert.Equal(t, durations, testutil.CollectAndCount(metrics.InvokeDuration))
+	assert.Equal(t, unknown+2, testutil.ToFloat64(metrics.Invocations.WithLabelValues(metrics.Unknown, metrics.Unknown, "default-resolver", "Unknown")))
+}
+
+const methodStoreTestProto = `
+name: "demo/greeter.proto"
+package: "demo"
+syntax: "proto3"
+message_type: {
+  name: "Hello"
+  field: { name: "name" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "name" }
+  field: { name: "greeting" number: 2 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "greeting" options: { deprecated: true } }
+}
+service: {
+  name: "Greeter"
+  method: { name: "Greet" input_type: ".demo.Hello" output_type: ".demo.Hello" options: { deprecated: true } }
+  method: { name: "Hello" input_type: ".demo.Hello" output_type: ".demo.Hello" }
+}
+`
+
+type countingMethodStore struct {
+	defaultRuntimeProtoStore
+	sd             protoreflect.ServiceDescriptor
+	methodLookups  int
+	messageLookups int
+}
+
+func (s *countingMethodStore) GetMethodDescriptor(_ context.Context, service, method string) (protoreflect.MethodDescriptor, error) {
+	s.methodLookups++
+	if md := s.sd.Methods().ByName(protoreflect.Name(method)); md != nil && service == string(s.sd.FullName()) {
+		return md, nil
+	}
+	return nil, errors.New("method not found")
+}
+
+func (s *countingMethodStore) GetMethodMessage(ctx context.Context, service, method string) (proto.Message, proto.Message, error) {
+	s.messageLookups++
+	return s.defaultRuntimeProtoStore.GetMethodMessage(ctx, service, method)
+}
+
+func TestInvokeResolvesMethodOnce(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	fdp := &descriptorpb.FileDescriptorProto{}
+	require.NoError(t, prototext.Unmarshal([]byte(methodStoreTestProto), fdp))
+	fd, err := protodesc.NewFile(fdp, nil)
+	require.NoError(t, err)
+	store := &countingMethodStore{sd: fd.Services().Get(0)}
+	ps := New(SetProtoStore(store))
+	backend := startBackend(t)
+
+	invoke := func(method string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest(http.MethodPost, "/invoke/demo.Greeter/"+method, strings.NewReader(`{"name": "berrypost"}`))
+		ctx.Request.Header.Set("X-Berrypost-Target", backend)
+		ctx.Params = gin.Params{{Key: "service", Value: "demo.Greeter"}, {Key: "method", Value: method}}
+		ps.ServeHTTP(ctx)
+		return w
+	}
+
+	// the backend has no services, the call fails after every check
+	w := invoke("Greet")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Header().Values("Warning"), `299 berrypost "method demo.Greeter.Greet is deprecated"`)
+	assert.Equal(t, 1, store.methodLookups)
+	assert.Equal(t, 0, store.messageLookups)
+
+	// unknown methods fall back to the messages of the store
+	invoke("Missing")
+	assert.Equal(t, 2, store.methodLookups)
+	assert.Equal(t, 1, store.messageLookups)
+}
+
+func TestInvokeDeprecationWarnings(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	fdp := &descriptorpb.FileDescriptorProto{}
+	require.NoError(t, prototext.Unmarshal([]byte(methodStoreTestProto), fdp))
+	fd, err := protodesc.NewFile(fdp, nil)
+	require.NoError(t, err)
+	ps := New(SetProtoStore(&countingMethodStore{sd: fd.Services().Get(0)}))
+	backend := startBackend(t)
+
+	warnings := func(method, body string) []string {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest(http.MethodPost, "/invoke/demo.Greeter/"+method, strings.NewReader(body))
+		ctx.Request.Header.Set("X-Berrypost-Target", backend)
+		ctx.Params = gin.Params{{Key: "service", Value: "demo.Greeter"}, {Key: "method", Value: method}}
+		ps.ServeHTTP(ctx)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		return w.Header().Values("Warning")
+	}
+
+	assert.Empty(t, warnings("Hello", `{"name": "berrypost"}`))
+	assert.Equal(t, []string{`299 berrypost "field greeting is deprecated"`}, warnings("Hello", `{"name": "berrypost", "greeting": "hi"}`))
+	assert.Equal(t, []string{
+		`299 berrypost "method demo.Greeter.Greet is deprecated"`,
+		`299 berrypost "field greeting is deprecated"`,
+	}, warnings("Greet", `{"greeting": "hi"}`))
+}
+
+// countingListener counts the connections accepted by a backend.
+type countingListener struct {
+	net.Listener
+	accepted int32
+}
+
+func (l *countingListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err == nil {
+		atomic.AddInt32(&l.accepted, 1)
+	}
+	return conn, err
+}
+
+func TestInvokeChecksRequestBeforeDial(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	fdp := &descriptorpb.FileDescriptorProto{}
+	require.NoError(t, prototext.Unmarshal([]byte(methodStoreTestProto), fdp))
+	fd, err := protodesc.NewFile(fdp, nil)
+	require.NoError(t, err)
+	ps := New(SetProtoStore(&countingMethodStore{sd: fd.Services().Get(0)}))
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	counting := &countingListener{Listener: lis}
+	srv := grpc.NewServer()
+	go srv.Serve(counting)
+	defer srv.Stop()
+
+	invoke := func(body string) error {
+		ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+		ctx.Request = httptest.NewRequest(http.MethodPost, "/invoke/demo.Greeter/Hello", strings.NewReader(body))
+		ctx.Request.Header.Set("X-Berrypost-Target", "tcp://"+lis.Addr().String())
+		ctx.Params = gin.Params{{Key: "service", Value: "demo.Greeter"}, {Key: "method", Value: "Hello"}}
+		ps.ServeHTTP(ctx)
+		require.NotNil(t, ctx.Errors.Last())
+		return ctx.Errors.Last().Err
+	}
+
+	err = invoke(`{"name": 1}`)
+	assert.Contains(t, err.Error(), "Invalid request message")
+	assert.Equal(t, int32(0), atomic.LoadInt32(&counting.accepted))
+
+	assert.Equal(t, codes.Unimplemented, status.Code(invoke(`{"name": "berrypost"}`)))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&counting.accepted))
+}
@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+	"github.com/realityone/berrypost/pkg/metrics"
+	"github.com/realityone/berrypost/pkg/server"
 	"github.com/sirupsen/logrus"
 )
 
@@ -53,15 +55,34 @@ type chainedRuntimeResolver struct {
 func (crr chainedRuntimeResolver) ResolveOnce(ctx context.Context, req *ResolveOnceRequest) (string, error) {
 	for _, r := range crr.all {
 		addr, err := r.ResolveOnce(ctx, req)
+		observeResolve(r.Name(), err)
 		if err != nil {
 			logrus.Warnf("Failed to resolve %+v with resolver %q: %+v", req, r.Name(), err)
 			continue
 		}
+		if proxyCtx, ok := fromContext(ctx); ok {
+			proxyCtx.resolver = r.Name()
+		}
 		return addr, nil
 	}
 	return "", errors.Errorf("Could not resolve service: %+v", req)
 }
 
+// Ready checks the resolvers of the chain which tell whether their backend is
+// reachable.
+func (crr chainedRuntimeResolver) Ready(ctx context.Context) error {
+	for _, r := range crr.all {
+		rc, ok := r.(server.ReadinessChecker)
+		if !ok {
+			continue
+		}
+		if err := rc.Ready(ctx); err != nil {
+			return errors.Wrapf(err, "resolver %q", r.Name())
+		}
+	}
+	return nil
+}
+
 func (crr chainedRuntimeResolver) Name() string {
 	names := make([]string, 0, len(crr.all))
 	for _, r := range crr.all {
@@ -70,6 +91,19 @@ func (crr chainedRuntimeResolver) Name() string {
 	return fmt.Sprintf("chained-resolver:%q", strings.Join(names, ">"))
 }
 
+// observeResolve counts the outcome of a resolver, resolvers passing to the
+// next one are counted as skipped.
+func observeResolve(name string, err error) {
+	result := "resolved"
+	switch {
+	case errors.Is(err, ToNextResolver):
+		result = "skipped"
+	case err != nil:
+		result = "error"
+	}
+	metrics.ResolverResults.WithLabelValues(name, result).Inc()
+}
+
 func ChainDefaultResolver(in ...RuntimeServiceResolver) RuntimeServiceResolver {
 	resolvers := []RuntimeServiceResolver{
 		defaultRuntimeServiceResolver{},
@@ -0,0 +1,291 @@
+package proxy
+
+import (
+	"container/list"
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/realityone/berrypost/pkg/metadata"
+	"github.com/realityone/berrypost/pkg/metrics"
+	"github.com/realityone/berrypost/pkg/protohelper"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+const (
+	defaultTypeCacheSize  = 64
+	defaultTypeCacheTTL   = 10 * time.Minute
+	defaultTypeMissesSize = 256
+)
+
+// TypeResolver resolves Any type URLs and extensions of a single call, it is
+// accepted by jsonpb as well as the protobuf v2 codecs.
+type TypeResolver interface {
+	jsonpb.AnyResolver
+	protoregistry.MessageTypeResolver
+	protoregistry.ExtensionTypeResolver
+}
+
+// RuntimeDescriptorStore is implemented by proto stores which can look up any
+// named descriptor, it allows resolving extensions by name.
+type RuntimeDescriptorStore interface {
+	FindDescriptor(context.Context, string) (protoreflect.Descriptor, error)
+}
+
+type typeScope struct {
+	revision  string
+	protoPath string
+}
+
+// scopedTypes holds the dynamic types of the files loaded in one scope, and
+// the names not found in the store until the scope expires. At most
+// defaultTypeMissesSize names are kept, the oldest one is forgotten first.
+type scopedTypes struct {
+	sync.RWMutex
+	types     *protoregistry.Types
+	files     map[string]struct{}
+	misses    map[protoreflect.FullName]*list.Element
+	missOrder *list.List
+	expires   time.Time
+}
+
+type typeCacheEntry struct {
+	scope typeScope
+	types *scopedTypes
+}
+
+// TypeCache keeps the types loaded from the proto store per proto revision
+// and proto path. At most size scopes are kept, the least recently used one
+// is evicted first, and scopes are reloaded after ttl so that updated proto
+// files are picked up.
+type TypeCache struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	lru     *list.List
+	entries map[typeScope]*list.Element
+}
+
+func NewTypeCache(size int, ttl time.Duration) *TypeCache {
+	if size <= 0 {
+		size = defaultTypeCacheSize
+	}
+	return &TypeCache{
+		size:    size,
+		ttl:     ttl,
+		lru:     list.New(),
+		entries: map[typeScope]*list.Element{},
+	}
+}
+
+func (c *TypeCache) scoped(scope typeScope) *scopedTypes {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	if elem, ok := c.entries[scope]; ok {
+		entry := elem.Value.(*typeCacheEntry)
+		if c.ttl <= 0 || now.Before(entry.types.expires) {
+			c.lru.MoveToFront(elem)
+			metrics.CacheHit("type_scope", true)
+			return entry.types
+		}
+		c.lru.Remove(elem)
+		delete(c.entries, scope)
+	}
+	metrics.CacheHit("type_scope", false)
+	types := &scopedTypes{
+		types:     &protoregistry.Types{},
+		files:     map[string]struct{}{},
+		misses:    map[protoreflect.FullName]*list.Element{},
+		missOrder: list.New(),
+		expires:   now.Add(c.ttl),
+	}
+	c.entries[scope] = c.lru.PushFront(&typeCacheEntry{scope: scope, types: types})
+	for c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*typeCacheEntry).scope)
+	}
+	return types
+}
+
+// Resolver returns the resolver of the proto revision and path in ctx, with
+// the types of files added. Types missing from the cache are loaded from the
+// store in the calling goroutine.
+func (c *TypeCache) Resolver(ctx context.Context, store RuntimeProtoStore, files ...protoreflect.FileDescriptor) TypeResolver {
+	meta, _ := metadata.FromContext(ctx)
+	r := &cachedTypeResolver{
+		ctx:   ctx,
+		store: store,
+		types: c.scoped(typeScope{revision: meta.ProtoRevision, protoPath: meta.ProtoPath}),
+	}
+	for _, fd := range files {
+		r.addFile(fd)
+	}
+	return r
+}
+
+type cachedTypeResolver struct {
+	ctx   context.Context
+	store RuntimeProtoStore
+	types *scopedTypes
+}
+
+// addFile registers the types of the file and its imports.
+func (r *cachedTypeResolver) addFile(fd protoreflect.FileDescriptor) {
+	r.types.RLock()
+	_, ok := r.types.files[fd.Path()]
+	r.types.RUnlock()
+	if ok {
+		return
+	}
+	r.types.Lock()
+	defer r.types.Unlock()
+	if _, ok := r.types.files[fd.Path()]; ok {
+		return
+	}
+	protohelper.AddDynamicTypes(r.types.types, fd)
+	r.types.files[fd.Path()] = struct{}{}
+}
+
+// load fetches the file declaring name from the store.
+func (r *cachedTypeResolver) load(name protoreflect.FullName) error {
+	if err := r.ctx.Err(); err != nil {
+		return errors.WithStack(err)
+	}
+	defer metrics.ObserveLookup("proto_store", "load_type", time.Now())
+	if ds, ok := r.store.(RuntimeDescriptorStore); ok {
+		d, err := ds.FindDescriptor(r.ctx, string(name))
+		if err != nil {
+			return err
+		}
+		r.addFile(d.ParentFile())
+		return nil
+	}
+	m, err := r.store.GetMessage(r.ctx, string(name))
+	if err != nil {
+		return err
+	}
+	r.addFile(proto.MessageReflect(m).Descriptor().ParentFile())
+	return nil
+}
+
+// missed reports whether name was not found in the store before.
+func (r *cachedTypeResolver) missed(name protoreflect.FullName) bool {
+	r.types.RLock()
+	defer r.types.RUnlock()
+	_, ok := r.types.misses[name]
+	return ok
+}
+
+// miss remembers a name not found in the store. Other errors, such as a
+// failing store or a cancelled call, are retried by the next lookup.
+func (r *cachedTypeResolver) miss(name protoreflect.FullName, err error) error {
+	if !errors.Is(err, protoregistry.NotFound) {
+		return err
+	}
+	r.types.Lock()
+	defer r.types.Unlock()
+	if _, ok := r.types.misses[name]; ok {
+		return err
+	}
+	r.types.misses[name] = r.types.missOrder.PushFront(name)
+	for r.types.missOrder.Len() > defaultTypeMissesSize {
+		oldest := r.types.missOrder.Back()
+		r.types.missOrder.Remove(oldest)
+		delete(r.types.misses, oldest.Value.(protoreflect.FullName))
+	}
+	return err
+}
+
+func (r *cachedTypeResolver) findMessage(name protoreflect.FullName) (protoreflect.MessageType, error) {
+	r.types.RLock()
+	defer r.types.RUnlock()
+	return r.types.types.FindMessageByName(name)
+}
+
+func (r *cachedTypeResolver) FindMessageByName(name protoreflect.FullName) (protoreflect.MessageType, error) {
+	if mt, err := r.findMessage(name); err == nil {
+		metrics.CacheHit("types", true)
+		return mt, nil
+	}
+	if mt, err := protoregistry.GlobalTypes.FindMessageByName(name); err == nil {
+		return mt, nil
+	}
+	if r.missed(name) {
+		metrics.CacheHit("types", true)
+		return nil, errors.Wrapf(protoregistry.NotFound, "resolve message %q", name)
+	}
+	metrics.CacheHit("types", false)
+	loadErr := r.load(name)
+	if mt, err := r.findMessage(name); err == nil {
+		return mt, nil
+	}
+	if loadErr != nil {
+		return nil, r.miss(name, errors.Wrapf(loadErr, "resolve message %q", name))
+	}
+	return nil, r.miss(name, protoregistry.NotFound)
+}
+
+func (r *cachedTypeResolver) FindMessageByURL(url string) (protoreflect.MessageType, error) {
+	return r.FindMessageByName(protoreflect.FullName(trimAnyTypePrefix(url)))
+}
+
+func (r *cachedTypeResolver) findExtensionByName(field protoreflect.FullName) (protoreflect.ExtensionType, error) {
+	r.types.RLock()
+	defer r.types.RUnlock()
+	return r.types.types.FindExtensionByName(field)
+}
+
+func (r *cachedTypeResolver) FindExtensionByName(field protoreflect.FullName) (protoreflect.ExtensionType, error) {
+	if xt, err := r.findExtensionByName(field); err == nil {
+		metrics.CacheHit("types", true)
+		return xt, nil
+	}
+	if xt, err := protoregistry.GlobalTypes.FindExtensionByName(field); err == nil {
+		return xt, nil
+	}
+	if r.missed(field) {
+		metrics.CacheHit("types", true)
+		return nil, errors.Wrapf(protoregistry.NotFound, "resolve extension %q", field)
+	}
+	metrics.CacheHit("types", false)
+	if _, ok := r.store.(RuntimeDescriptorStore); ok {
+		if err := r.load(field); err != nil {
+			return nil, r.miss(field, errors.Wrapf(err, "resolve extension %q", field))
+		}
+	}
+	if xt, err := r.findExtensionByName(field); err == nil {
+		return xt, nil
+	}
+	return nil, r.miss(field, protoregistry.NotFound)
+}
+
+// FindExtensionByNumber only knows extensions of the files loaded so far,
+// which include the files of the request and reply of the call.
+func (r *cachedTypeResolver) FindExtensionByNumber(message protoreflect.FullName, field protoreflect.FieldNumber) (protoreflect.ExtensionType, error) {
+	r.types.RLock()
+	xt, err := r.types.types.FindExtensionByNumber(message, field)
+	r.types.RUnlock()
+	if err == nil {
+		return xt, nil
+	}
+	return protoregistry.GlobalTypes.FindExtensionByNumber(message, field)
+}
+
+func (r *cachedTypeResolver) Resolve(typeURL string) (proto.Message, error) {
+	mt, err := r.FindMessageByURL(typeURL)
+	if err != nil {
+		return nil, err
+	}
+	return proto.MessageV1(mt.New().Interface()), nil
+}
+
+func trimAnyTypePrefix(typeURL string) string {
+	return typeURL[strings.LastIndex(typeURL, "/")+1:]
+}
@@ -0,0 +1,217 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrNoCredentials is returned by providers if the request carries no
+// credentials they understand, the next provider is tried then.
+var ErrNoCredentials = errors.New("no credentials")
+
+// Identity is the authenticated user of a request.
+type Identity struct {
+	Subject  string   `json:"subject"`
+	Name     string   `json:"name,omitempty"`
+	Email    string   `json:"email,omitempty"`
+	Groups   []string `json:"groups,omitempty"`
+	Provider string   `json:"provider"`
+}
+
+// InGroup reports whether the identity is a member of group.
+func (i *Identity) InGroup(group string) bool {
+	for _, g := range i.Groups {
+		if g == group {
+			return true
+		}
+	}
+	return false
+}
+
+const ContextKey = "berrypost-identity-key"
+
+// FromContext returns the identity of the request, ctx is a *gin.Context or a
+// context derived from the request context.
+func FromContext(ctx context.Context) (*Identity, bool) {
+	identity, ok := ctx.Value(ContextKey).(*Identity)
+	return identity, ok && identity != nil
+}
+
+// NewContext returns a copy of ctx carrying the identity.
+func NewContext(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, ContextKey, identity)
+}
+
+type Provider interface {
+	Name() string
+	// Authenticate returns ErrNoCredentials if the request has none for the
+	// provider, any other error rejects the credentials.
+	Authenticate(*http.Request) (*Identity, error)
+}
+
+// Challenger is implemented by providers which can ask for credentials, like
+// redirecting a browser to a login page.
+type Challenger interface {
+	Challenge(*gin.Context) bool
+}
+
+// RouteProvider is implemented by providers serving their own endpoints, the
+// routes are public.
+type RouteProvider interface {
+	Routes() []Route
+}
+
+type Route struct {
+	Method  string
+	Path    string
+	Handler gin.HandlerFunc
+}
+
+type Option func(*Authenticator)
+
+// Authenticator tries the providers in order, the first identity wins.
+type Authenticator struct {
+	providers   []Provider
+	publicPaths []string
+}
+
+func New(opts ...Option) *Authenticator {
+	a := &Authenticator{}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+func SetProviders(in ...Provider) Option {
+	return func(a *Authenticator) {
+		a.providers = in
+	}
+}
+
+// SetPublicPaths sets the paths served without authentication, a trailing *
+// matches any suffix, e.g. /assets/*.
+func SetPublicPaths(in ...string) Option {
+	return func(a *Authenticator) {
+		a.publicPaths = in
+	}
+}
+
+func (a *Authenticator) routes() []Route {
+	out := []Route{}
+	for _, p := range a.providers {
+		if rp, ok := p.(RouteProvider); ok {
+			out = append(out, rp.Routes()...)
+		}
+	}
+	return out
+}
+
+func (a *Authenticator) isPublic(path string) bool {
+	for _, p := range a.publicPaths {
+		if strings.HasSuffix(p, "*") && strings.HasPrefix(path, strings.TrimSuffix(p, "*")) {
+			return true
+		}
+		if p == path {
+			return true
+		}
+	}
+	for _, r := range a.routes() {
+		if r.Path == path {
+			return true
+		}
+	}
+	return false
+}
+
+// Authenticate returns the identity of the request, ErrNoCredentials if no
+// provider found credentials.
+func (a *Authenticator) Authenticate(req *http.Request) (*Identity, error) {
+	var rejected error
+	for _, p := range a.providers {
+		identity, err := p.Authenticate(req)
+		if err == nil {
+			identity.Provider = p.Name()
+			return identity, nil
+		}
+		if !errors.Is(err, ErrNoCredentials) && rejected == nil {
+			rejected = errors.Wrapf(err, "%s", p.Name())
+		}
+	}
+	if rejected != nil {
+		return nil, rejected
+	}
+	return nil, ErrNoCredentials
+}
+
+// Middleware authenticates every request except the public paths, the
+// identity is stored in both the gin context and the request context.
+func (a *Authenticator) Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if a.isPublic(ctx.Request.URL.Path) {
+			ctx.Next()
+			return
+		}
+		identity, err := a.Authenticate(ctx.Request)
+		if err != nil {
+			a.reject(ctx, err)
+			return
+		}
+		ctx.Set(ContextKey, identity)
+		ctx.Request = ctx.Request.WithContext(NewContext(ctx.Request.Context(), identity))
+		ctx.Next()
+	}
+}
+
+func (a *Authenticator) reject(ctx *gin.Context, err error) {
+	if errors.Is(err, ErrNoCredentials) {
+		for _, p := range a.providers {
+			if c, ok := p.(Challenger); ok && c.Challenge(ctx) {
+				ctx.Abort()
+				return
+			}
+		}
+	} else {
+		logrus.Warnf("Failed to authenticate request on %q: %+v", ctx.Request.URL.Path, err)
+	}
+	for _, p := range a.providers {
+		switch p.(type) {
+		case *BasicProvider:
+			ctx.Writer.Header().Add("WWW-Authenticate", `Basic realm="berrypost", charset="UTF-8"`)
+		case *TokenProvider:
+			ctx.Writer.Header().Add("WWW-Authenticate", `Bearer realm="berrypost"`)
+		}
+	}
+	abortUnauthorized(ctx, err)
+}
+
+// abortUnauthorized replies in the shape of the JSON error handler.
+func abortUnauthorized(ctx *gin.Context, err error) {
+	ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+		"code":    "unauthorized",
+		"message": "Unauthorized",
+		"detail":  gin.H{"error": err.Error()},
+	})
+}
+
+// Setup registers the routes of the providers and /api/_whoami.
+func (a *Authenticator) Setup(r gin.IRoutes) {
+	for _, route := range a.routes() {
+		r.Handle(route.Method, route.Path, route.Handler)
+	}
+	r.GET("/api/_whoami", whoami)
+}
+
+func whoami(ctx *gin.Context) {
+	identity, ok := FromContext(ctx)
+	if !ok {
+		abortUnauthorized(ctx, ErrNoCredentials)
+		return
+	}
+	ctx.JSON(http.StatusOK, identity)
+}
@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func writeTestFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "auth")
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func newTestEngine(a *Authenticator) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(a.Middleware())
+	a.Setup(engine)
+	engine.GET("/api/_intro", func(ctx *gin.Context) { ctx.String(http.StatusOK, "intro") })
+	engine.GET("/api/secret", func(ctx *gin.Context) {
+		identity, _ := FromContext(ctx)
+		fromRequest, _ := FromContext(ctx.Request.Context())
+		ctx.String(http.StatusOK, "%s/%s", identity.Subject, fromRequest.Provider)
+	})
+	return engine
+}
+
+func serve(engine http.Handler, req *http.Request) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	return w
+}
+
+func TestAuthenticatorProviders(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("s3cret"), bcrypt.MinCost)
+	require.NoError(t, err)
+	basic, err := LoadHtpasswd(writeTestFile(t, fmt.Sprintf("# users\nalice:%s:dev,ops\nbob:{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g=\n", hash)))
+	require.NoError(t, err)
+	tokens, err := LoadTokenFile(writeTestFile(t, "ci:ci-token:deployers\n"))
+	require.NoError(t, err)
+	proxyHeaders, err := NewProxyHeaderProvider([]string{"10.0.0.0/8"})
+	require.NoError(t, err)
+
+	engine := newTestEngine(New(SetProviders(basic, tokens, proxyHeaders), SetPublicPaths("/api/_intro")))
+
+	w := serve(engine, httptest.NewRequest(http.MethodGet, "/api/_intro", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = serve(engine, httptest.NewRequest(http.MethodGet, "/api/secret", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Len(t, w.Header().Values("WWW-Authenticate"), 2)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/secret", nil)
+	req.SetBasicAuth("alice", "s3cret")
+	w = serve(engine, req)
+	assert.Equal(t, "alice/basic", w.Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, "/api/secret", nil)
+	req.SetBasicAuth("bob", "password")
+	assert.Equal(t, "bob/basic", serve(engine, req).Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, "/api/secret", nil)
+	req.SetBasicAuth("alice", "wrong")
+	assert.Equal(t, http.StatusUnauthorized, serve(engine, req).Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/_whoami", nil)
+	req.Header.Set("Authorization", "Bearer ci-token")
+	w = serve(engine, req)
+	assert.JSONEq(t, `{"subject": "ci", "name": "ci", "groups": ["deployers"], "provider": "token"}`, w.Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, "/api/secret", nil)
+	req.Header.Set("Authorization", "Bearer unknown")
+	assert.Equal(t, http.StatusUnauthorized, serve(engine, req).Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/secret", nil)
+	req.RemoteAddr = "10.1.2.3:5000"
+	req.Header.Set("X-Forwarded-User", "carol")
+	assert.Equal(t, "carol/proxy", serve(engine, req).Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, "/api/secret", nil)
+	req.RemoteAddr = "192.168.1.1:5000"
+	req.Header.Set("X-Forwarded-User", "carol")
+	assert.Equal(t, http.StatusUnauthorized, serve(engine, req).Code)
+}
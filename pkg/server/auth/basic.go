@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// StaticUser is a user of basic authentication. PasswordHash is a bcrypt hash
+// or a {SHA} hash as written by htpasswd -s.
+type StaticUser struct {
+	Name         string
+	PasswordHash string
+	Groups       []string
+}
+
+// BasicProvider authenticates HTTP basic credentials against static users.
+type BasicProvider struct {
+	users map[string]*StaticUser
+}
+
+func NewBasicProvider(users ...*StaticUser) *BasicProvider {
+	p := &BasicProvider{users: map[string]*StaticUser{}}
+	for _, u := range users {
+		p.users[u.Name] = u
+	}
+	return p
+}
+
+// LoadHtpasswd reads users from an htpasswd file, an optional third column
+// lists the groups of the user separated by commas.
+func LoadHtpasswd(path string) (*BasicProvider, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer f.Close()
+
+	users := []*StaticUser{}
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		parts := strings.SplitN(text, ":", 3)
+		if len(parts) < 2 || parts[0] == "" {
+			return nil, errors.Errorf("%s:%d: expecting user:hash", path, line)
+		}
+		if !isSupportedHash(parts[1]) {
+			return nil, errors.Errorf("%s:%d: unsupported password hash of user %q, use bcrypt", path, line, parts[0])
+		}
+		user := &StaticUser{Name: parts[0], PasswordHash: parts[1]}
+		if len(parts) == 3 && parts[2] != "" {
+			user.Groups = strings.Split(parts[2], ",")
+		}
+		users = append(users, user)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return NewBasicProvider(users...), nil
+}
+
+func isSupportedHash(hash string) bool {
+	return strings.HasPrefix(hash, "$2") || strings.HasPrefix(hash, "{SHA}")
+}
+
+func checkPassword(hash, password string) bool {
+	if strings.HasPrefix(hash, "{SHA}") {
+		sum := sha1.Sum([]byte(password))
+		expected := "{SHA}" + base64.StdEncoding.EncodeToString(sum[:])
+		return subtle.ConstantTimeCompare([]byte(hash), []byte(expected)) == 1
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+func (*BasicProvider) Name() string { return "basic" }
+
+func (p *BasicProvider) Authenticate(req *http.Request) (*Identity, error) {
+	name, password, ok := req.BasicAuth()
+	if !ok {
+		return nil, ErrNoCredentials
+	}
+	user, ok := p.users[name]
+	if !ok || !checkPassword(user.PasswordHash, password) {
+		return nil, errors.Errorf("invalid password of user %q", name)
+	}
+	return &Identity{Subject: user.Name, Name: user.Name, Groups: user.Groups}, nil
+}
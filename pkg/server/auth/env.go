@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"os"
+	"strings"
+)
+
+func envList(key string) []string {
+	out := []string{}
+	for _, v := range strings.Split(os.Getenv(key), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// FromEnv builds the authenticator from the environment, it returns nil if no
+// provider is configured. The providers are tried in the order below.
+//
+//	BERRYPOST_AUTH_HTPASSWD         htpasswd file of basic auth users
+//	BERRYPOST_AUTH_TOKENS           file of subject:token[:groups] lines
+//	BERRYPOST_AUTH_TRUSTED_PROXIES  CIDRs allowed to set X-Forwarded-User
+//	BERRYPOST_OIDC_ISSUER           issuer URL, with BERRYPOST_OIDC_CLIENT_ID,
+//	                                BERRYPOST_OIDC_CLIENT_SECRET,
+//	                                BERRYPOST_OIDC_REDIRECT_URL and
+//	                                BERRYPOST_OIDC_SESSION_KEY
+//	BERRYPOST_AUTH_PUBLIC_PATHS     comma separated public paths
+func FromEnv() (*Authenticator, error) {
+	providers := []Provider{}
+	if path := os.Getenv("BERRYPOST_AUTH_HTPASSWD"); path != "" {
+		p, err := LoadHtpasswd(path)
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, p)
+	}
+	if path := os.Getenv("BERRYPOST_AUTH_TOKENS"); path != "" {
+		p, err := LoadTokenFile(path)
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, p)
+	}
+	if trusted := envList("BERRYPOST_AUTH_TRUSTED_PROXIES"); len(trusted) > 0 {
+		p, err := NewProxyHeaderProvider(trusted)
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, p)
+	}
+	if issuer := os.Getenv("BERRYPOST_OIDC_ISSUER"); issuer != "" {
+		providers = append(providers, NewOIDCProvider(OIDCConfig{
+			Issuer:       issuer,
+			ClientID:     os.Getenv("BERRYPOST_OIDC_CLIENT_ID"),
+			ClientSecret: os.Getenv("BERRYPOST_OIDC_CLIENT_SECRET"),
+			RedirectURL:  os.Getenv("BERRYPOST_OIDC_REDIRECT_URL"),
+			SessionKey:   []byte(os.Getenv("BERRYPOST_OIDC_SESSION_KEY")),
+		}))
+	}
+	if len(providers) <= 0 {
+		return nil, nil
+	}
+	return New(SetProviders(providers...), SetPublicPaths(envList("BERRYPOST_AUTH_PUBLIC_PATHS")...)), nil
+}
@@ -0,0 +1,216 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	clockSkew        = time.Minute
+	jwksRefetchAfter = time.Minute
+)
+
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var multi []string
+	if err := json.Unmarshal(data, &multi); err != nil {
+		return err
+	}
+	*a = multi
+	return nil
+}
+
+func (a audience) contains(v string) bool {
+	for _, item := range a {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
+
+type idTokenClaims struct {
+	Issuer            string   `json:"iss"`
+	Subject           string   `json:"sub"`
+	Audience          audience `json:"aud"`
+	Expiry            int64    `json:"exp"`
+	NotBefore         int64    `json:"nbf"`
+	Nonce             string   `json:"nonce"`
+	Email             string   `json:"email"`
+	Name              string   `json:"name"`
+	PreferredUsername string   `json:"preferred_username"`
+
+	raw map[string]json.RawMessage
+}
+
+// groups reads a string or string list claim.
+func (c *idTokenClaims) groups(claim string) []string {
+	raw, ok := c.raw[claim]
+	if !ok {
+		return nil
+	}
+	var out audience
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil
+	}
+	return out
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// keySet caches the RSA keys of a JWKS endpoint, it is fetched again when a
+// token is signed by an unknown key, at most once per jwksRefetchAfter.
+type keySet struct {
+	sync.Mutex
+	uri     string
+	client  *http.Client
+	keys    map[string]*rsa.PublicKey
+	fetched time.Time
+}
+
+func (ks *keySet) fetch() error {
+	resp, err := ks.client.Get(ks.uri)
+	if err != nil {
+		return errors.Wrap(err, "fetch jwks")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("fetch jwks: unexpected status %s", resp.Status)
+	}
+	doc := struct {
+		Keys []*jwk `json:"keys"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return errors.Wrap(err, "decode jwks")
+	}
+	keys := map[string]*rsa.PublicKey{}
+	for _, k := range doc.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			continue
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			continue
+		}
+		keys[k.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+	ks.keys = keys
+	return nil
+}
+
+func (ks *keySet) lookup(kid string) *rsa.PublicKey {
+	if key, ok := ks.keys[kid]; ok {
+		return key
+	}
+	if kid == "" && len(ks.keys) == 1 {
+		for _, key := range ks.keys {
+			return key
+		}
+	}
+	return nil
+}
+
+func (ks *keySet) key(kid string) (*rsa.PublicKey, error) {
+	ks.Lock()
+	defer ks.Unlock()
+	if key := ks.lookup(kid); key != nil {
+		return key, nil
+	}
+	if time.Since(ks.fetched) < jwksRefetchAfter {
+		return nil, errors.Errorf("unknown signing key %q", kid)
+	}
+	ks.fetched = time.Now()
+	if err := ks.fetch(); err != nil {
+		return nil, err
+	}
+	if key := ks.lookup(kid); key != nil {
+		return key, nil
+	}
+	return nil, errors.Errorf("unknown signing key %q", kid)
+}
+
+// verifyIDToken checks the RS256 signature and the registered claims of a
+// compact JWT.
+func verifyIDToken(token string, keys *keySet, issuer, clientID string, now time.Time) (*idTokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed jwt")
+	}
+	rawHeader, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, errors.Wrap(err, "decode jwt header")
+	}
+	header := struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}{}
+	if err := json.Unmarshal(rawHeader, &header); err != nil {
+		return nil, errors.Wrap(err, "decode jwt header")
+	}
+	if header.Alg != "RS256" {
+		return nil, errors.Errorf("unsupported jwt algorithm %q", header.Alg)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.Wrap(err, "decode jwt signature")
+	}
+	key, err := keys.key(header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return nil, errors.New("invalid jwt signature")
+	}
+
+	rawClaims, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, errors.Wrap(err, "decode jwt claims")
+	}
+	claims := &idTokenClaims{}
+	if err := json.Unmarshal(rawClaims, claims); err != nil {
+		return nil, errors.Wrap(err, "decode jwt claims")
+	}
+	if err := json.Unmarshal(rawClaims, &claims.raw); err != nil {
+		return nil, errors.Wrap(err, "decode jwt claims")
+	}
+	switch {
+	case claims.Issuer != issuer:
+		return nil, errors.Errorf("unexpected issuer %q", claims.Issuer)
+	case !claims.Audience.contains(clientID):
+		return nil, errors.Errorf("token is not issued for %q", clientID)
+	case claims.Subject == "":
+		return nil, errors.New("missing subject")
+	case now.After(time.Unix(claims.Expiry, 0).Add(clockSkew)):
+		return nil, errors.New("token is expired")
+	case claims.NotBefore != 0 && now.Add(clockSkew).Before(time.Unix(claims.NotBefore, 0)):
+		return nil, errors.New("token is not valid yet")
+	}
+	return claims, nil
+}
@@ -0,0 +1,341 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	sessionCookieName = "berrypost_session"
+	loginCookieName   = "berrypost_oidc"
+	loginTimeout      = 10 * time.Minute
+
+	LoginPath    = "/auth/login"
+	CallbackPath = "/auth/callback"
+	LogoutPath   = "/auth/logout"
+)
+
+type OIDCConfig struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is the absolute URL of CallbackPath, derived from the
+	// request if empty.
+	RedirectURL string
+	// Scopes defaults to openid, profile and email.
+	Scopes []string
+	// GroupsClaim defaults to groups.
+	GroupsClaim string
+	// SessionKey signs the session cookies, sessions do not survive restarts
+	// if it is empty.
+	SessionKey []byte
+	// SessionTTL defaults to 12 hours.
+	SessionTTL time.Duration
+	HTTPClient *http.Client
+}
+
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// OIDCProvider logs users in with the authorization code flow and PKCE, the
+// identity is kept in a signed session cookie. ID tokens of the issuer are
+// also accepted as bearer tokens.
+type OIDCProvider struct {
+	cfg     OIDCConfig
+	cookies signedCookies
+
+	mu        sync.Mutex
+	discovery *oidcDiscovery
+	keys      *keySet
+}
+
+type loginState struct {
+	State    string `json:"state"`
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+	Redirect string `json:"redirect"`
+}
+
+func NewOIDCProvider(cfg OIDCConfig) *OIDCProvider {
+	if len(cfg.Scopes) <= 0 {
+		cfg.Scopes = []string{"openid", "profile", "email"}
+	}
+	if cfg.GroupsClaim == "" {
+		cfg.GroupsClaim = "groups"
+	}
+	if len(cfg.SessionKey) <= 0 {
+		cfg.SessionKey = []byte(randomString(32))
+	}
+	if cfg.SessionTTL <= 0 {
+		cfg.SessionTTL = 12 * time.Hour
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	cfg.Issuer = strings.TrimSuffix(cfg.Issuer, "/")
+	return &OIDCProvider{cfg: cfg, cookies: signedCookies{key: cfg.SessionKey}}
+}
+
+// discover fetches the provider metadata once it is needed, so the issuer
+// does not have to be reachable on start.
+func (p *OIDCProvider) discover() (*oidcDiscovery, *keySet, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.discovery != nil {
+		return p.discovery, p.keys, nil
+	}
+	resp, err := p.cfg.HTTPClient.Get(p.cfg.Issuer + "/.well-known/openid-configuration")
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "oidc discovery")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, errors.Errorf("oidc discovery: unexpected status %s", resp.Status)
+	}
+	discovery := &oidcDiscovery{}
+	if err := json.NewDecoder(resp.Body).Decode(discovery); err != nil {
+		return nil, nil, errors.Wrap(err, "oidc discovery")
+	}
+	if strings.TrimSuffix(discovery.Issuer, "/") != p.cfg.Issuer {
+		return nil, nil, errors.Errorf("oidc discovery: issuer %q does not match %q", discovery.Issuer, p.cfg.Issuer)
+	}
+	p.discovery = discovery
+	p.keys = &keySet{uri: discovery.JWKSURI, client: p.cfg.HTTPClient}
+	return p.discovery, p.keys, nil
+}
+
+func (*OIDCProvider) Name() string { return "oidc" }
+
+func (p *OIDCProvider) Authenticate(req *http.Request) (*Identity, error) {
+	// an expired or foreign session is treated as none, to log in again
+	if cookie, err := req.Cookie(sessionCookieName); err == nil {
+		identity := &Identity{}
+		if err := p.cookies.decode(cookie.Value, identity, time.Now()); err == nil && identity.Subject != "" {
+			return identity, nil
+		}
+	}
+	token, ok := bearerToken(req)
+	if !ok || strings.Count(token, ".") != 2 {
+		return nil, ErrNoCredentials
+	}
+	claims, err := p.verify(token, "")
+	if err != nil {
+		return nil, err
+	}
+	return p.identityOf(claims), nil
+}
+
+func (p *OIDCProvider) verify(token, nonce string) (*idTokenClaims, error) {
+	discovery, keys, err := p.discover()
+	if err != nil {
+		return nil, err
+	}
+	claims, err := verifyIDToken(token, keys, strings.TrimSuffix(discovery.Issuer, "/"), p.cfg.ClientID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if nonce != "" && claims.Nonce != nonce {
+		return nil, errors.New("nonce mismatch")
+	}
+	return claims, nil
+}
+
+func (p *OIDCProvider) identityOf(claims *idTokenClaims) *Identity {
+	name := claims.PreferredUsername
+	if name == "" {
+		name = claims.Name
+	}
+	return &Identity{
+		Subject: claims.Subject,
+		Name:    name,
+		Email:   claims.Email,
+		Groups:  claims.groups(p.cfg.GroupsClaim),
+	}
+}
+
+// Challenge redirects browsers to the login page.
+func (p *OIDCProvider) Challenge(ctx *gin.Context) bool {
+	if ctx.Request.Method != http.MethodGet || !strings.Contains(ctx.GetHeader("Accept"), "text/html") {
+		return false
+	}
+	ctx.Redirect(http.StatusFound, LoginPath+"?redirect="+url.QueryEscape(ctx.Request.URL.RequestURI()))
+	return true
+}
+
+func (p *OIDCProvider) Routes() []Route {
+	return []Route{
+		{Method: http.MethodGet, Path: LoginPath, Handler: p.login},
+		{Method: http.MethodGet, Path: CallbackPath, Handler: p.callback},
+		{Method: http.MethodGet, Path: LogoutPath, Handler: p.logout},
+	}
+}
+
+func (p *OIDCProvider) redirectURL(req *http.Request) string {
+	if p.cfg.RedirectURL != "" {
+		return p.cfg.RedirectURL
+	}
+	scheme := "http"
+	if req.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + req.Host + CallbackPath
+}
+
+// localRedirect only allows paths of this server, to not be an open redirect.
+func localRedirect(in string) string {
+	if !strings.HasPrefix(in, "/") || strings.HasPrefix(in, "//") || strings.HasPrefix(in, "/\\") {
+		return "/"
+	}
+	return in
+}
+
+func pkceChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func (p *OIDCProvider) login(ctx *gin.Context) {
+	discovery, _, err := p.discover()
+	if err != nil {
+		logrus.Errorf("Failed to start oidc login: %+v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadGateway, gin.H{
+			"code":    "bad_gateway",
+			"message": "Bad Gateway",
+			"detail":  gin.H{"error": err.Error()},
+		})
+		return
+	}
+	state := &loginState{
+		State:    randomString(16),
+		Nonce:    randomString(16),
+		Verifier: randomString(32),
+		Redirect: localRedirect(ctx.Query("redirect")),
+	}
+	if err := p.cookies.set(ctx.Writer, ctx.Request, loginCookieName, state, loginTimeout); err != nil {
+		ctx.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.cfg.ClientID},
+		"redirect_uri":          {p.redirectURL(ctx.Request)},
+		"scope":                 {strings.Join(p.cfg.Scopes, " ")},
+		"state":                 {state.State},
+		"nonce":                 {state.Nonce},
+		"code_challenge":        {pkceChallenge(state.Verifier)},
+		"code_challenge_method": {"S256"},
+	}
+	target := discovery.AuthorizationEndpoint
+	if strings.Contains(target, "?") {
+		target += "&" + query.Encode()
+	} else {
+		target += "?" + query.Encode()
+	}
+	ctx.Redirect(http.StatusFound, target)
+}
+
+func (p *OIDCProvider) exchange(req *http.Request, tokenEndpoint, code, verifier string) (string, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.redirectURL(req)},
+		"client_id":     {p.cfg.ClientID},
+		"code_verifier": {verifier},
+	}
+	tokenReq, err := http.NewRequestWithContext(req.Context(), http.MethodPost, tokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	tokenReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	tokenReq.Header.Set("Accept", "application/json")
+	if p.cfg.ClientSecret != "" {
+		tokenReq.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+	}
+	resp, err := p.cfg.HTTPClient.Do(tokenReq)
+	if err != nil {
+		return "", errors.Wrap(err, "exchange code")
+	}
+	defer resp.Body.Close()
+	out := struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", errors.Wrapf(err, "exchange code: status %s", resp.Status)
+	}
+	if out.Error != "" {
+		return "", errors.Errorf("exchange code: %s: %s", out.Error, out.ErrorDescription)
+	}
+	if out.IDToken == "" {
+		return "", errors.New("exchange code: no id_token in response")
+	}
+	return out.IDToken, nil
+}
+
+func (p *OIDCProvider) callback(ctx *gin.Context) {
+	fail := func(err error) {
+		logrus.Warnf("Failed to complete oidc login: %+v", err)
+		abortUnauthorized(ctx, err)
+	}
+	if e := ctx.Query("error"); e != "" {
+		fail(errors.Errorf("%s: %s", e, ctx.Query("error_description")))
+		return
+	}
+	cookie, err := ctx.Request.Cookie(loginCookieName)
+	if err != nil {
+		fail(errors.New("login is not started or has expired"))
+		return
+	}
+	clearCookie(ctx.Writer, loginCookieName)
+	state := &loginState{}
+	if err := p.cookies.decode(cookie.Value, state, time.Now()); err != nil {
+		fail(err)
+		return
+	}
+	if ctx.Query("state") != state.State {
+		fail(errors.New("state mismatch"))
+		return
+	}
+	discovery, _, err := p.discover()
+	if err != nil {
+		fail(err)
+		return
+	}
+	idToken, err := p.exchange(ctx.Request, discovery.TokenEndpoint, ctx.Query("code"), state.Verifier)
+	if err != nil {
+		fail(err)
+		return
+	}
+	claims, err := p.verify(idToken, state.Nonce)
+	if err != nil {
+		fail(err)
+		return
+	}
+	identity := p.identityOf(claims)
+	if err := p.cookies.set(ctx.Writer, ctx.Request, sessionCookieName, identity, p.cfg.SessionTTL); err != nil {
+		fail(err)
+		return
+	}
+	logrus.Infof("User %q logged in with oidc", identity.Subject)
+	ctx.Redirect(http.StatusFound, state.Redirect)
+}
+
+func (p *OIDCProvider) logout(ctx *gin.Context) {
+	clearCookie(ctx.Writer, sessionCookieName)
+	ctx.Redirect(http.StatusFound, "/")
+}
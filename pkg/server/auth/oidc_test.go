@@ -0,0 +1,161 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testIssuer is a stand-in OIDC issuer which approves every login.
+type testIssuer struct {
+	*httptest.Server
+	key   *rsa.PrivateKey
+	codes map[string]url.Values
+}
+
+func (ti *testIssuer) sign(t *testing.T, claims map[string]interface{}) string {
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": "test"})
+	payload, _ := json.Marshal(claims)
+	signing := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signing))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, ti.key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	return signing + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func (ti *testIssuer) claims(subject, nonce string) map[string]interface{} {
+	return map[string]interface{}{
+		"iss":                ti.URL,
+		"sub":                subject,
+		"aud":                []string{"berrypost"},
+		"exp":                time.Now().Add(time.Hour).Unix(),
+		"nonce":              nonce,
+		"preferred_username": "dave",
+		"groups":             []string{"dev"},
+	}
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ti := &testIssuer{key: key, codes: map[string]url.Values{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 ti.URL,
+			"authorization_endpoint": ti.URL + "/authorize",
+			"token_endpoint":         ti.URL + "/token",
+			"jwks_uri":               ti.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "test",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/authorize", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		ti.codes["code-1"] = query
+		http.Redirect(w, r, query.Get("redirect_uri")+"?code=code-1&state="+url.QueryEscape(query.Get("state")), http.StatusFound)
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		authorized, ok := ti.codes[r.PostForm.Get("code")]
+		user, password, _ := r.BasicAuth()
+		if !ok || user != "berrypost" || password != "secret" ||
+			pkceChallenge(r.PostForm.Get("code_verifier")) != authorized.Get("code_challenge") {
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"id_token": ti.sign(t, ti.claims("user-1", authorized.Get("nonce")))})
+	})
+	ti.Server = httptest.NewServer(mux)
+	t.Cleanup(ti.Close)
+	return ti
+}
+
+func TestOIDCLoginFlow(t *testing.T) {
+	issuer := newTestIssuer(t)
+	provider := NewOIDCProvider(OIDCConfig{
+		Issuer:       issuer.URL,
+		ClientID:     "berrypost",
+		ClientSecret: "secret",
+		RedirectURL:  "http://berrypost.local" + CallbackPath,
+	})
+	engine := newTestEngine(New(SetProviders(provider)))
+
+	// browsers are sent to the login page
+	req := httptest.NewRequest(http.MethodGet, "/api/secret?x=1", nil)
+	req.Header.Set("Accept", "text/html")
+	w := serve(engine, req)
+	require.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, LoginPath+"?redirect=%2Fapi%2Fsecret%3Fx%3D1", w.Header().Get("Location"))
+
+	w = serve(engine, httptest.NewRequest(http.MethodGet, w.Header().Get("Location"), nil))
+	require.Equal(t, http.StatusFound, w.Code)
+	loginCookies := w.Result().Cookies()
+
+	// the stand-in issuer approves and redirects back with a code
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := client.Get(w.Header().Get("Location"))
+	require.NoError(t, err)
+	callback, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+
+	req = httptest.NewRequest(http.MethodGet, callback.RequestURI(), nil)
+	for _, c := range loginCookies {
+		req.AddCookie(c)
+	}
+	w = serve(engine, req)
+	require.Equal(t, http.StatusFound, w.Code, w.Body.String())
+	assert.Equal(t, "/api/secret?x=1", w.Header().Get("Location"))
+
+	req = httptest.NewRequest(http.MethodGet, "/api/_whoami", nil)
+	for _, c := range w.Result().Cookies() {
+		if c.Name == sessionCookieName {
+			req.AddCookie(c)
+		}
+	}
+	assert.JSONEq(t, `{"subject": "user-1", "name": "dave", "groups": ["dev"], "provider": "oidc"}`, serve(engine, req).Body.String())
+
+	// a replayed callback has no login state anymore
+	req = httptest.NewRequest(http.MethodGet, callback.RequestURI(), nil)
+	assert.Equal(t, http.StatusUnauthorized, serve(engine, req).Code)
+}
+
+func TestOIDCBearerToken(t *testing.T) {
+	issuer := newTestIssuer(t)
+	provider := NewOIDCProvider(OIDCConfig{Issuer: issuer.URL, ClientID: "berrypost"})
+	engine := newTestEngine(New(SetProviders(NewTokenProvider(), provider)))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/secret", nil)
+	req.Header.Set("Authorization", "Bearer "+issuer.sign(t, issuer.claims("user-2", "")))
+	assert.Equal(t, "user-2/oidc", serve(engine, req).Body.String())
+
+	expired := issuer.claims("user-2", "")
+	expired["exp"] = time.Now().Add(-time.Hour).Unix()
+	req = httptest.NewRequest(http.MethodGet, "/api/secret", nil)
+	req.Header.Set("Authorization", "Bearer "+issuer.sign(t, expired))
+	assert.Equal(t, http.StatusUnauthorized, serve(engine, req).Code)
+
+	foreign := issuer.claims("user-2", "")
+	foreign["aud"] = "another-client"
+	req = httptest.NewRequest(http.MethodGet, "/api/secret", nil)
+	req.Header.Set("Authorization", "Bearer "+issuer.sign(t, foreign))
+	assert.Equal(t, http.StatusUnauthorized, serve(engine, req).Code)
+}
@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ProxyHeaderProvider trusts the identity headers set by an authenticating
+// reverse proxy, only for requests coming from the trusted networks.
+type ProxyHeaderProvider struct {
+	trusted      []*net.IPNet
+	userHeader   string
+	emailHeader  string
+	groupsHeader string
+}
+
+type ProxyHeaderOpt func(*ProxyHeaderProvider)
+
+// NewProxyHeaderProvider trusts peers in the CIDRs, a plain IP is taken as a
+// single address. By default X-Forwarded-User, X-Forwarded-Email and
+// X-Forwarded-Groups are read.
+func NewProxyHeaderProvider(trustedCIDRs []string, opts ...ProxyHeaderOpt) (*ProxyHeaderProvider, error) {
+	p := &ProxyHeaderProvider{
+		userHeader:   "X-Forwarded-User",
+		emailHeader:  "X-Forwarded-Email",
+		groupsHeader: "X-Forwarded-Groups",
+	}
+	for _, cidr := range trustedCIDRs {
+		if !strings.Contains(cidr, "/") {
+			if ip := net.ParseIP(cidr); ip != nil && ip.To4() != nil {
+				cidr += "/32"
+			} else {
+				cidr += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid trusted proxy: %q", cidr)
+		}
+		p.trusted = append(p.trusted, network)
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p, nil
+}
+
+func SetProxyUserHeader(in string) ProxyHeaderOpt {
+	return func(p *ProxyHeaderProvider) {
+		p.userHeader = in
+	}
+}
+
+func SetProxyEmailHeader(in string) ProxyHeaderOpt {
+	return func(p *ProxyHeaderProvider) {
+		p.emailHeader = in
+	}
+}
+
+func SetProxyGroupsHeader(in string) ProxyHeaderOpt {
+	return func(p *ProxyHeaderProvider) {
+		p.groupsHeader = in
+	}
+}
+
+func (p *ProxyHeaderProvider) isTrusted(remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, network := range p.trusted {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func (*ProxyHeaderProvider) Name() string { return "proxy" }
+
+func (p *ProxyHeaderProvider) Authenticate(req *http.Request) (*Identity, error) {
+	user := req.Header.Get(p.userHeader)
+	if user == "" {
+		return nil, ErrNoCredentials
+	}
+	if !p.isTrusted(req.RemoteAddr) {
+		return nil, errors.Errorf("%s from untrusted peer %s", p.userHeader, req.RemoteAddr)
+	}
+	identity := &Identity{Subject: user, Name: user, Email: req.Header.Get(p.emailHeader)}
+	for _, g := range strings.Split(req.Header.Get(p.groupsHeader), ",") {
+		if g = strings.TrimSpace(g); g != "" {
+			identity.Groups = append(identity.Groups, g)
+		}
+	}
+	return identity, nil
+}
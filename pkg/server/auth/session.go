@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// signedCookies encodes values as base64 JSON with an HMAC-SHA256 signature.
+type signedCookies struct {
+	key []byte
+}
+
+type signedValue struct {
+	Expires int64           `json:"exp"`
+	Value   json.RawMessage `json:"v"`
+}
+
+func randomString(n int) string {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+func (c signedCookies) sign(payload string) string {
+	mac := hmac.New(sha256.New, c.key)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (c signedCookies) encode(v interface{}, expires time.Time) (string, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	data, err := json.Marshal(&signedValue{Expires: expires.Unix(), Value: raw})
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	payload := base64.RawURLEncoding.EncodeToString(data)
+	return payload + "." + c.sign(payload), nil
+}
+
+func (c signedCookies) decode(in string, v interface{}, now time.Time) error {
+	pos := strings.LastIndex(in, ".")
+	if pos < 0 {
+		return errors.New("malformed cookie")
+	}
+	payload, signature := in[:pos], in[pos+1:]
+	if !hmac.Equal([]byte(signature), []byte(c.sign(payload))) {
+		return errors.New("invalid cookie signature")
+	}
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	sv := &signedValue{}
+	if err := json.Unmarshal(data, sv); err != nil {
+		return errors.WithStack(err)
+	}
+	if now.After(time.Unix(sv.Expires, 0)) {
+		return errors.New("cookie is expired")
+	}
+	return errors.WithStack(json.Unmarshal(sv.Value, v))
+}
+
+func (c signedCookies) set(w http.ResponseWriter, req *http.Request, name string, v interface{}, ttl time.Duration) error {
+	expires := time.Now().Add(ttl)
+	value, err := c.encode(v, expires)
+	if err != nil {
+		return err
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		Expires:  expires,
+		HttpOnly: true,
+		Secure:   req.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+	return nil
+}
+
+func clearCookie(w http.ResponseWriter, name string) {
+	http.SetCookie(w, &http.Cookie{Name: name, Path: "/", MaxAge: -1, HttpOnly: true})
+}
@@ -0,0 +1,117 @@
+package auth
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// TokenProvider authenticates static bearer tokens. Tokens are kept as
+// SHA-256 digests only.
+type TokenProvider struct {
+	tokens map[[sha256.Size]byte]*Identity
+}
+
+func NewTokenProvider() *TokenProvider {
+	return &TokenProvider{tokens: map[[sha256.Size]byte]*Identity{}}
+}
+
+// AddToken accepts token as the credential of identity.
+func (p *TokenProvider) AddToken(token string, identity *Identity) {
+	p.tokens[sha256.Sum256([]byte(token))] = identity
+}
+
+// AddTokenDigest accepts the token of the hex encoded SHA-256 digest.
+func (p *TokenProvider) AddTokenDigest(digest string, identity *Identity) error {
+	raw, err := hex.DecodeString(digest)
+	if err != nil || len(raw) != sha256.Size {
+		return errors.Errorf("invalid sha256 digest: %q", digest)
+	}
+	var key [sha256.Size]byte
+	copy(key[:], raw)
+	p.tokens[key] = identity
+	return nil
+}
+
+// LoadTokenFile reads lines of subject:token[:group,group], the token may be
+// given as sha256:<hex digest> to keep it out of the file.
+func LoadTokenFile(path string) (*TokenProvider, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer f.Close()
+
+	p := NewTokenProvider()
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		subject, rest := text, ""
+		if pos := strings.Index(text, ":"); pos >= 0 {
+			subject, rest = text[:pos], text[pos+1:]
+		}
+		token, groups := rest, ""
+		if strings.HasPrefix(rest, "sha256:") {
+			token = strings.TrimPrefix(rest, "sha256:")
+		}
+		if pos := strings.LastIndex(token, ":"); pos >= 0 {
+			token, groups = token[:pos], token[pos+1:]
+		}
+		if subject == "" || token == "" {
+			return nil, errors.Errorf("%s:%d: expecting subject:token", path, line)
+		}
+		identity := &Identity{Subject: subject, Name: subject}
+		if groups != "" {
+			identity.Groups = strings.Split(groups, ",")
+		}
+		if strings.HasPrefix(rest, "sha256:") {
+			if err := p.AddTokenDigest(token, identity); err != nil {
+				return nil, errors.Wrapf(err, "%s:%d", path, line)
+			}
+			continue
+		}
+		p.AddToken(token, identity)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return p, nil
+}
+
+func bearerToken(req *http.Request) (string, bool) {
+	v := req.Header.Get("Authorization")
+	if len(v) < 7 || !strings.EqualFold(v[:7], "bearer ") {
+		return "", false
+	}
+	return strings.TrimSpace(v[7:]), true
+}
+
+func (*TokenProvider) Name() string { return "token" }
+
+func (p *TokenProvider) Authenticate(req *http.Request) (*Identity, error) {
+	token, ok := bearerToken(req)
+	if !ok {
+		return nil, ErrNoCredentials
+	}
+	digest := sha256.Sum256([]byte(token))
+	for key, identity := range p.tokens {
+		if subtle.ConstantTimeCompare(key[:], digest[:]) == 1 {
+			out := *identity
+			return &out, nil
+		}
+	}
+	// JWTs are left to the OIDC provider.
+	if strings.Count(token, ".") == 2 {
+		return nil, ErrNoCredentials
+	}
+	return nil, errors.New("unknown bearer token")
+}
@@ -0,0 +1,173 @@
+package cors
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Config lets tools on other origins call berrypost. Origins and headers
+// accept * as a wildcard, e.g. https://*.example.com or X-Berrypost-*.
+type Config struct {
+	AllowOrigins     []string
+	AllowMethods     []string
+	AllowHeaders     []string
+	ExposeHeaders    []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+// DefaultConfig allows the berrypost control and metadata headers.
+func DefaultConfig(origins ...string) Config {
+	return Config{
+		AllowOrigins:  origins,
+		AllowMethods:  []string{http.MethodGet, http.MethodPost},
+		AllowHeaders:  []string{"Accept", "Authorization", "Content-Type", "X-Berrypost-*"},
+		ExposeHeaders: []string{"Warning", "X-Berrypost-*"},
+		MaxAge:        10 * time.Minute,
+	}
+}
+
+type matcher []*regexp.Regexp
+
+func newMatcher(patterns []string) matcher {
+	out := make(matcher, 0, len(patterns))
+	for _, p := range patterns {
+		parts := strings.Split(p, "*")
+		for i, part := range parts {
+			parts[i] = regexp.QuoteMeta(part)
+		}
+		out = append(out, regexp.MustCompile("(?i)^"+strings.Join(parts, ".*")+"$"))
+	}
+	return out
+}
+
+func (m matcher) match(in string) bool {
+	for _, re := range m {
+		if re.MatchString(in) {
+			return true
+		}
+	}
+	return false
+}
+
+type handler struct {
+	cfg     Config
+	origins matcher
+	headers matcher
+	exposed matcher
+}
+
+// New returns the middleware answering preflight requests and adding the CORS
+// headers to the replies of allowed origins. It goes before authentication,
+// preflight requests carry no credentials.
+func New(cfg Config) gin.HandlerFunc {
+	h := &handler{
+		cfg:     cfg,
+		origins: newMatcher(cfg.AllowOrigins),
+		headers: newMatcher(cfg.AllowHeaders),
+		exposed: newMatcher(cfg.ExposeHeaders),
+	}
+	return h.handle
+}
+
+func (h *handler) handle(ctx *gin.Context) {
+	origin := ctx.GetHeader("Origin")
+	if origin == "" || !h.origins.match(origin) {
+		ctx.Next()
+		return
+	}
+	header := ctx.Writer.Header()
+	header.Add("Vary", "Origin")
+	header.Set("Access-Control-Allow-Origin", origin)
+	if h.cfg.AllowCredentials {
+		header.Set("Access-Control-Allow-Credentials", "true")
+	}
+
+	requestMethod := ctx.GetHeader("Access-Control-Request-Method")
+	if ctx.Request.Method == http.MethodOptions && requestMethod != "" {
+		h.preflight(ctx, requestMethod)
+		return
+	}
+	ctx.Writer = &exposingWriter{ResponseWriter: ctx.Writer, exposed: h.exposed}
+	ctx.Next()
+}
+
+func (h *handler) preflight(ctx *gin.Context, requestMethod string) {
+	header := ctx.Writer.Header()
+	header.Add("Vary", "Access-Control-Request-Method")
+	header.Add("Vary", "Access-Control-Request-Headers")
+	allowed := false
+	for _, m := range h.cfg.AllowMethods {
+		if strings.EqualFold(m, requestMethod) {
+			allowed = true
+		}
+	}
+	if !allowed {
+		ctx.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+	requested := []string{}
+	for _, v := range strings.Split(ctx.GetHeader("Access-Control-Request-Headers"), ",") {
+		if v = strings.TrimSpace(v); v == "" {
+			continue
+		}
+		if !h.headers.match(v) {
+			ctx.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+		requested = append(requested, v)
+	}
+	header.Set("Access-Control-Allow-Methods", strings.Join(h.cfg.AllowMethods, ", "))
+	if len(requested) > 0 {
+		header.Set("Access-Control-Allow-Headers", strings.Join(requested, ", "))
+	}
+	if h.cfg.MaxAge > 0 {
+		header.Set("Access-Control-Max-Age", strconv.Itoa(int(h.cfg.MaxAge.Seconds())))
+	}
+	ctx.AbortWithStatus(http.StatusNoContent)
+}
+
+// exposingWriter lists the reply headers matching the exposed patterns in
+// Access-Control-Expose-Headers, as the header does not take wildcards with
+// credentials.
+type exposingWriter struct {
+	gin.ResponseWriter
+	exposed matcher
+	done    bool
+}
+
+func (w *exposingWriter) expose() {
+	if w.done || w.Written() {
+		return
+	}
+	w.done = true
+	names := []string{}
+	for k := range w.Header() {
+		if w.exposed.match(k) {
+			names = append(names, k)
+		}
+	}
+	if len(names) > 0 {
+		w.Header().Set("Access-Control-Expose-Headers", strings.Join(names, ", "))
+	}
+}
+
+func (w *exposingWriter) WriteHeaderNow() {
+	w.expose()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *exposingWriter) Write(data []byte) (int, error) {
+	w.expose()
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *exposingWriter) WriteString(s string) (int, error) {
+	w.expose()
+	return w.ResponseWriter.WriteString(s)
+}
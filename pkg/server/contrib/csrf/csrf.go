@@ -0,0 +1,156 @@
+package csrf
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	CookieName = "berrypost_csrf"
+	HeaderName = "X-Berrypost-Csrf-Token"
+
+	tokenKey = "berrypost-csrf-token-key"
+)
+
+type Option func(*Protector)
+
+// SetTrustedOrigins allows state changing requests from other origins, like
+// the ones allowed by CORS. Patterns accept * as a wildcard.
+func SetTrustedOrigins(in ...string) Option {
+	return func(p *Protector) {
+		p.trusted = p.trusted[:0]
+		for _, o := range in {
+			parts := strings.Split(o, "*")
+			for i, part := range parts {
+				parts[i] = regexp.QuoteMeta(part)
+			}
+			p.trusted = append(p.trusted, regexp.MustCompile("(?i)^"+strings.Join(parts, ".*")+"$"))
+		}
+	}
+}
+
+// Protector rejects cross-site state changing requests. The origin of
+// POST, PUT, PATCH and DELETE requests has to be this server or trusted, and
+// browsers holding the CSRF cookie have to echo its token in HeaderName.
+// Clients sending neither Origin, Referer nor cookies are not browsers and
+// pass.
+type Protector struct {
+	trusted []*regexp.Regexp
+}
+
+func New(opts ...Option) *Protector {
+	p := &Protector{}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+func randomToken() string {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+// Token returns the CSRF token of the browser, issuing the cookie if there is
+// none. Pages render it for their scripts to send in HeaderName.
+func Token(ctx *gin.Context) string {
+	if token := ctx.GetString(tokenKey); token != "" {
+		return token
+	}
+	if cookie, err := ctx.Request.Cookie(CookieName); err == nil && cookie.Value != "" {
+		return cookie.Value
+	}
+	token := randomToken()
+	http.SetCookie(ctx.Writer, &http.Cookie{
+		Name:     CookieName,
+		Value:    token,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   ctx.Request.TLS != nil,
+		SameSite: http.SameSiteStrictMode,
+	})
+	ctx.Set(tokenKey, token)
+	return token
+}
+
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return false
+}
+
+// requestOrigin returns the origin of the request, from Origin or Referer.
+func requestOrigin(req *http.Request) string {
+	if origin := req.Header.Get("Origin"); origin != "" {
+		return origin
+	}
+	referer, err := url.Parse(req.Header.Get("Referer"))
+	if err != nil || referer.Host == "" {
+		return ""
+	}
+	return referer.Scheme + "://" + referer.Host
+}
+
+func (p *Protector) isTrusted(origin string) bool {
+	for _, re := range p.trusted {
+		if re.MatchString(origin) {
+			return true
+		}
+	}
+	return false
+}
+
+func isSameOrigin(req *http.Request, origin string) bool {
+	parsed, err := url.Parse(origin)
+	return err == nil && parsed.Host != "" && strings.EqualFold(parsed.Host, req.Host)
+}
+
+// Middleware checks the state changing requests, it goes after the CORS
+// middleware.
+func (p *Protector) Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if isSafeMethod(ctx.Request.Method) {
+			ctx.Next()
+			return
+		}
+		origin := requestOrigin(ctx.Request)
+		if origin != "" && !isSameOrigin(ctx.Request, origin) {
+			if !p.isTrusted(origin) {
+				abortForbidden(ctx, "cross-origin request from "+origin+" is not allowed")
+				return
+			}
+			// trusted origins can not read the token of this server
+			ctx.Next()
+			return
+		}
+		if cookie, err := ctx.Request.Cookie(CookieName); err == nil {
+			token := ctx.GetHeader(HeaderName)
+			if subtle.ConstantTimeCompare([]byte(token), []byte(cookie.Value)) != 1 {
+				abortForbidden(ctx, "missing or invalid "+HeaderName)
+				return
+			}
+		}
+		ctx.Next()
+	}
+}
+
+// abortForbidden replies in the shape of the JSON error handler.
+func abortForbidden(ctx *gin.Context, reason string) {
+	ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+		"code":    "forbidden",
+		"message": "Forbidden",
+		"detail":  gin.H{"error": "csrf: " + reason},
+	})
+}
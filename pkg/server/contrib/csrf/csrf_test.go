@@ -0,0 +1,85 @@
+package csrf
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/realityone/berrypost/pkg/server/contrib/cors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEngine() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	corsConfig := cors.DefaultConfig("https://tools.example.com")
+	engine.Use(cors.New(corsConfig), New(SetTrustedOrigins(corsConfig.AllowOrigins...)).Middleware())
+	engine.GET("/page", func(ctx *gin.Context) { ctx.String(http.StatusOK, Token(ctx)) })
+	engine.POST("/invoke", func(ctx *gin.Context) {
+		ctx.Header("X-Berrypost-Md-Server", "demo")
+		ctx.String(http.StatusOK, "ok")
+	})
+	return engine
+}
+
+func serve(engine http.Handler, req *http.Request) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	return w
+}
+
+func TestProtector(t *testing.T) {
+	engine := newTestEngine()
+
+	page := serve(engine, httptest.NewRequest(http.MethodGet, "http://berrypost.local/page", nil))
+	token := page.Body.String()
+	require.NotEmpty(t, token)
+	cookies := page.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, http.SameSiteStrictMode, cookies[0].SameSite)
+
+	post := func(origin, token string, withCookie bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "http://berrypost.local/invoke", nil)
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		if token != "" {
+			req.Header.Set(HeaderName, token)
+		}
+		if withCookie {
+			req.AddCookie(cookies[0])
+		}
+		return serve(engine, req)
+	}
+
+	// scripts without a browser
+	assert.Equal(t, http.StatusOK, post("", "", false).Code)
+	// the invoke page
+	assert.Equal(t, http.StatusOK, post("http://berrypost.local", token, true).Code)
+	assert.Equal(t, http.StatusForbidden, post("http://berrypost.local", "", true).Code)
+	assert.Equal(t, http.StatusForbidden, post("", "forged", true).Code)
+	// other sites
+	assert.Equal(t, http.StatusForbidden, post("https://evil.example.com", token, true).Code)
+	req := httptest.NewRequest(http.MethodPost, "http://berrypost.local/invoke", nil)
+	req.Header.Set("Referer", "https://evil.example.com/form")
+	assert.Equal(t, http.StatusForbidden, serve(engine, req).Code)
+
+	// trusted tools, with CORS
+	w := post("https://tools.example.com", "", true)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "https://tools.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "X-Berrypost-Md-Server", w.Header().Get("Access-Control-Expose-Headers"))
+
+	req = httptest.NewRequest(http.MethodOptions, "http://berrypost.local/invoke", nil)
+	req.Header.Set("Origin", "https://tools.example.com")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	req.Header.Set("Access-Control-Request-Headers", "content-type, x-berrypost-target")
+	w = serve(engine, req)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "content-type, x-berrypost-target", w.Header().Get("Access-Control-Allow-Headers"))
+
+	req.Header.Set("Access-Control-Request-Headers", "x-unknown")
+	assert.Equal(t, http.StatusForbidden, serve(engine, req).Code)
+}
@@ -0,0 +1,201 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const readyCheckTimeout = 5 * time.Second
+
+// Starter is implemented by components initialising once the server serves,
+// like proto loaders and watchers. The context is cancelled on shutdown.
+type Starter interface {
+	Start(context.Context) error
+}
+
+// Stopper is implemented by components releasing resources on shutdown, it
+// is called after the in-flight requests are drained.
+type Stopper interface {
+	Stop(context.Context) error
+}
+
+// ReadinessChecker is implemented by components depending on something to
+// serve, like compiled protos or a reachable resolver backend.
+type ReadinessChecker interface {
+	Ready(context.Context) error
+}
+
+// MandatoryComponent is implemented by components the server can not serve
+// without. Mandatory components failing to set up or start block readiness,
+// other components failing are logged and skipped.
+type MandatoryComponent interface {
+	Mandatory() bool
+}
+
+func isMandatory(c Component) bool {
+	m, ok := c.(MandatoryComponent)
+	return ok && m.Mandatory()
+}
+
+const (
+	ComponentStarting = "starting"
+	ComponentReady    = "ready"
+	ComponentNotReady = "not_ready"
+	ComponentFailed   = "failed"
+)
+
+// componentState tracks the lifecycle of a component.
+type componentState struct {
+	component Component
+	mandatory bool
+
+	mu      sync.Mutex
+	started bool
+	failed  error
+}
+
+func newComponentState(c Component) *componentState {
+	st := &componentState{component: c, mandatory: isMandatory(c)}
+	// components without Start are started once set up
+	if _, ok := c.(Starter); !ok {
+		st.started = true
+	}
+	return st
+}
+
+func (st *componentState) fail(err error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.failed = err
+}
+
+func (st *componentState) setStarted() {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.started = true
+}
+
+// ComponentHealth is the state of a component in the health and readiness
+// replies.
+type ComponentHealth struct {
+	Status    string `json:"status"`
+	Mandatory bool   `json:"mandatory"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Health aggregates the state of the components, it is ok unless a
+// mandatory component is not.
+type Health struct {
+	Status     string                      `json:"status"`
+	Components map[string]*ComponentHealth `json:"components"`
+}
+
+func (st *componentState) health(ctx context.Context, checkReady bool) *ComponentHealth {
+	st.mu.Lock()
+	started, failed := st.started, st.failed
+	st.mu.Unlock()
+
+	out := &ComponentHealth{Status: ComponentReady, Mandatory: st.mandatory}
+	switch {
+	case failed != nil:
+		out.Status, out.Error = ComponentFailed, failed.Error()
+	case !started:
+		out.Status = ComponentStarting
+	case checkReady:
+		if rc, ok := st.component.(ReadinessChecker); ok {
+			if err := rc.Ready(ctx); err != nil {
+				out.Status, out.Error = ComponentNotReady, err.Error()
+			}
+		}
+	}
+	return out
+}
+
+// health reports the failed components, readiness also reports the ones
+// starting or not ready.
+func (s *Server) health(ctx context.Context, checkReady bool) *Health {
+	out := &Health{Status: "ok", Components: map[string]*ComponentHealth{}}
+	for _, st := range s.states {
+		h := st.health(ctx, checkReady)
+		out.Components[st.component.Name()] = h
+		if !st.mandatory {
+			continue
+		}
+		if h.Status == ComponentFailed || (checkReady && h.Status != ComponentReady) {
+			out.Status = "unavailable"
+		}
+	}
+	return out
+}
+
+func replyHealth(ctx *gin.Context, h *Health) {
+	code := http.StatusOK
+	if h.Status != "ok" {
+		code = http.StatusServiceUnavailable
+	}
+	ctx.JSON(code, h)
+}
+
+func (s *Server) healthz(ctx *gin.Context) {
+	replyHealth(ctx, s.health(ctx, false))
+}
+
+func (s *Server) readyz(ctx *gin.Context) {
+	checkCtx, cancel := context.WithTimeout(ctx.Request.Context(), readyCheckTimeout)
+	defer cancel()
+	replyHealth(ctx, s.health(checkCtx, true))
+}
+
+// startComponents starts the components in order, a component failing to
+// start does not stop the others.
+func (s *Server) startComponents(ctx context.Context) {
+	for _, st := range s.states {
+		starter, ok := st.component.(Starter)
+		if !ok {
+			continue
+		}
+		st.mu.Lock()
+		failed := st.failed
+		st.mu.Unlock()
+		if failed != nil {
+			continue
+		}
+		if err := starter.Start(ctx); err != nil {
+			logrus.Errorf("Failed to start component: %+v: %+v", st.component.Name(), err)
+			st.fail(errors.Wrap(err, "start"))
+			continue
+		}
+		st.setStarted()
+	}
+}
+
+// stopComponents stops the started components in reverse order.
+func (s *Server) stopComponents(ctx context.Context) error {
+	var stopErr error
+	for i := len(s.states) - 1; i >= 0; i-- {
+		st := s.states[i]
+		stopper, ok := st.component.(Stopper)
+		if !ok {
+			continue
+		}
+		st.mu.Lock()
+		started := st.started
+		st.mu.Unlock()
+		if !started {
+			continue
+		}
+		if err := stopper.Stop(ctx); err != nil {
+			logrus.Errorf("Failed to stop component: %+v: %+v", st.component.Name(), err)
+			if stopErr == nil {
+				stopErr = errors.Wrapf(err, "stop component %q", st.component.Name())
+			}
+		}
+	}
+	return stopErr
+}
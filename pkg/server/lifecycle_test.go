@@ -0,0 +1,112 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/realityone/berrypost/pkg/server/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testComponent struct {
+	name      string
+	mandatory bool
+	setupErr  error
+	ready     error
+	start     chan struct{}
+	events    *[]string
+}
+
+func (c *testComponent) Name() string                { return c.name }
+func (c *testComponent) Meta() map[string]string     { return nil }
+func (c *testComponent) Setup(*Server) error         { return c.setupErr }
+func (c *testComponent) Mandatory() bool             { return c.mandatory }
+func (c *testComponent) Ready(context.Context) error { return c.ready }
+
+func (c *testComponent) Start(ctx context.Context) error {
+	select {
+	case <-c.start:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	*c.events = append(*c.events, "start "+c.name)
+	return nil
+}
+
+func (c *testComponent) Stop(context.Context) error {
+	*c.events = append(*c.events, "stop "+c.name)
+	return nil
+}
+
+func probe(t *testing.T, s *Server, path string) (int, *Health) {
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	h := &Health{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), h))
+	return w.Code, h
+}
+
+func TestComponentLifecycle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	events := []string{}
+	protos := &testComponent{name: "protos", mandatory: true, start: make(chan struct{}), events: &events}
+	resolver := &testComponent{name: "resolver", mandatory: true, start: make(chan struct{}), events: &events}
+	plugin := &testComponent{name: "plugin", setupErr: errors.New("broken"), start: make(chan struct{}), events: &events}
+	close(resolver.start)
+	s := New(
+		SetGinMiddlewares(nil),
+		SetComponents([]Component{protos, resolver, plugin}),
+		// probes are public
+		SetAuthenticator(auth.New()),
+		SetListenAddr("unix:"+filepath.Join(t.TempDir(), "berrypost.sock")),
+	)
+
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/_intro", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	code, h := probe(t, s, "/api/_health")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, ComponentFailed, h.Components["plugin"].Status)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- s.ServeContext(ctx) }()
+
+	code, h = probe(t, s, "/api/_ready")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, ComponentStarting, h.Components["protos"].Status)
+
+	close(protos.start)
+	require.Eventually(t, func() bool {
+		code, _ := probe(t, s, "/api/_ready")
+		return code == http.StatusOK
+	}, time.Second, 10*time.Millisecond)
+
+	resolver.ready = errors.New("registry unreachable")
+	code, h = probe(t, s, "/api/_ready")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, ComponentNotReady, h.Components["resolver"].Status)
+	assert.Equal(t, "registry unreachable", h.Components["resolver"].Error)
+	code, _ = probe(t, s, "/api/_health")
+	assert.Equal(t, http.StatusOK, code)
+
+	cancel()
+	require.NoError(t, <-served)
+	assert.Equal(t, []string{"start protos", "start resolver", "stop resolver", "stop protos"}, events)
+
+	// a mandatory component failing to set up is never ready
+	broken := New(SetGinMiddlewares(nil), SetComponents([]Component{
+		&testComponent{name: "protos", mandatory: true, setupErr: errors.New("no protos"), events: &events},
+	}))
+	code, h = probe(t, broken, "/api/_health")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "setup: no protos", h.Components["protos"].Error)
+}
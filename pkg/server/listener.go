@@ -0,0 +1,239 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const unixAddrPrefix = "unix:"
+
+// ListenConfig configures how the server listens and shuts down.
+type ListenConfig struct {
+	// Addr is host:port, or unix:/path/to/socket for a unix socket.
+	Addr string
+	// CertFile and KeyFile serve HTTPS, they are reloaded once changed.
+	CertFile string
+	KeyFile  string
+	Timeouts Timeouts
+}
+
+// Timeouts of the HTTP server. Write covers the whole invocation, it has to
+// be longer than the slowest RPC.
+type Timeouts struct {
+	ReadHeader time.Duration
+	Read       time.Duration
+	Write      time.Duration
+	Idle       time.Duration
+	// Shutdown is how long in-flight requests are drained for on shutdown.
+	Shutdown time.Duration
+}
+
+func DefaultListenConfig() ListenConfig {
+	return ListenConfig{
+		Addr: "0.0.0.0:8000",
+		Timeouts: Timeouts{
+			ReadHeader: 10 * time.Second,
+			Read:       time.Minute,
+			Write:      5 * time.Minute,
+			Idle:       2 * time.Minute,
+			Shutdown:   30 * time.Second,
+		},
+	}
+}
+
+// SetListenAddr sets the address to listen on, host:port or
+// unix:/path/to/socket.
+func SetListenAddr(in string) Option {
+	return func(sc *ServerConfig) {
+		sc.Listen.Addr = in
+	}
+}
+
+// SetTLSCertificate serves HTTPS with the certificate and key files.
+func SetTLSCertificate(certFile, keyFile string) Option {
+	return func(sc *ServerConfig) {
+		sc.Listen.CertFile = certFile
+		sc.Listen.KeyFile = keyFile
+	}
+}
+
+func SetTimeouts(in Timeouts) Option {
+	return func(sc *ServerConfig) {
+		sc.Listen.Timeouts = in
+	}
+}
+
+// certReloader loads the certificate again once its files are modified, so
+// that renewed certificates are served without a restart.
+type certReloader struct {
+	certFile string
+	keyFile  string
+
+	mu      sync.Mutex
+	cert    *tls.Certificate
+	modTime time.Time
+}
+
+func newCertReloader(certFile, keyFile string) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *certReloader) latestModTime() (time.Time, error) {
+	latest := time.Time{}
+	for _, path := range []string{r.certFile, r.keyFile} {
+		info, err := os.Stat(path)
+		if err != nil {
+			return time.Time{}, errors.WithStack(err)
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+func (r *certReloader) reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	modTime, err := r.latestModTime()
+	if err != nil {
+		return err
+	}
+	if r.cert != nil && !modTime.After(r.modTime) {
+		return nil
+	}
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return errors.Wrapf(err, "load certificate %q", r.certFile)
+	}
+	if r.cert != nil {
+		logrus.Infof("Reloaded TLS certificate: %q", r.certFile)
+	}
+	r.cert, r.modTime = &cert, modTime
+	return nil
+}
+
+// GetCertificate keeps serving the loaded certificate if reloading fails, the
+// files may be in the middle of being replaced.
+func (r *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	if err := r.reload(); err != nil {
+		logrus.Warnf("Failed to reload TLS certificate: %+v", err)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cert, nil
+}
+
+func listen(addr string) (net.Listener, error) {
+	if !strings.HasPrefix(addr, unixAddrPrefix) {
+		lis, err := net.Listen("tcp", addr)
+		return lis, errors.WithStack(err)
+	}
+	path := strings.TrimPrefix(strings.TrimPrefix(addr, unixAddrPrefix), "//")
+	// a socket left by a previous process refuses to be bound again
+	if info, err := os.Stat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+		if err := os.Remove(path); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+	lis, err := net.Listen("unix", path)
+	return lis, errors.WithStack(err)
+}
+
+func (s *Server) httpServer() (*http.Server, error) {
+	cfg := s.listen
+	srv := &http.Server{
+		Handler:           s,
+		ReadHeaderTimeout: cfg.Timeouts.ReadHeader,
+		ReadTimeout:       cfg.Timeouts.Read,
+		WriteTimeout:      cfg.Timeouts.Write,
+		IdleTimeout:       cfg.Timeouts.Idle,
+	}
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		reloader, err := newCertReloader(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		srv.TLSConfig = &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: reloader.GetCertificate,
+		}
+	}
+	return srv, nil
+}
+
+// Serve serves until SIGINT or SIGTERM, then drains the in-flight requests.
+func (s *Server) Serve() error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	return s.ServeContext(ctx)
+}
+
+// ServeContext starts the components and serves until ctx is done, then
+// drains the in-flight requests and stops the components, for at most the
+// shutdown timeout.
+func (s *Server) ServeContext(ctx context.Context) error {
+	srv, err := s.httpServer()
+	if err != nil {
+		return err
+	}
+	lis, err := listen(s.listen.Addr)
+	if err != nil {
+		return err
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		if srv.TLSConfig != nil {
+			serveErr <- srv.ServeTLS(lis, "", "")
+			return
+		}
+		serveErr <- srv.Serve(lis)
+	}()
+	logrus.Infof("Starting server listen and serve at: %s...", s.listen.Addr)
+
+	startCtx, cancelStart := context.WithCancel(ctx)
+	defer cancelStart()
+	started := make(chan struct{})
+	go func() {
+		defer close(started)
+		s.startComponents(startCtx)
+	}()
+
+	select {
+	case err = <-serveErr:
+		err = errors.WithStack(err)
+	case <-ctx.Done():
+	}
+	logrus.Infof("Shutting down server, draining in-flight requests for at most %s...", s.listen.Timeouts.Shutdown)
+	shutdownCtx := context.Background()
+	if s.listen.Timeouts.Shutdown > 0 {
+		var cancel context.CancelFunc
+		shutdownCtx, cancel = context.WithTimeout(shutdownCtx, s.listen.Timeouts.Shutdown)
+		defer cancel()
+	}
+	if shutdownErr := srv.Shutdown(shutdownCtx); shutdownErr != nil && err == nil {
+		err = errors.Wrap(shutdownErr, "shutdown server")
+	}
+	cancelStart()
+	<-started
+	if stopErr := s.stopComponents(shutdownCtx); stopErr != nil && err == nil {
+		err = stopErr
+	}
+	return err
+}
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServeContextDrains(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	socket := filepath.Join(t.TempDir(), "berrypost.sock")
+	s := New(SetGinMiddlewares(nil), SetListenAddr("unix:"+socket))
+	started := make(chan struct{})
+	s.GET("/slow", func(ctx *gin.Context) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		ctx.String(http.StatusOK, "done")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- s.ServeContext(ctx) }()
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+		},
+	}}
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(socket)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+
+	replied := make(chan string, 1)
+	go func() {
+		resp, err := client.Get("http://berrypost/slow")
+		if err != nil {
+			replied <- err.Error()
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := ioutil.ReadAll(resp.Body)
+		replied <- string(body)
+	}()
+	<-started
+	cancel()
+	assert.Equal(t, "done", <-replied)
+	assert.NoError(t, <-served)
+}
+
+func writeCertificate(t *testing.T, dir, commonName string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	require.NoError(t, ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certFile, keyFile
+}
+
+func TestCertReloader(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeCertificate(t, dir, "first")
+	r, err := newCertReloader(certFile, keyFile)
+	require.NoError(t, err)
+
+	commonName := func() string {
+		cert, err := r.GetCertificate(nil)
+		require.NoError(t, err)
+		leaf, err := x509.ParseCertificate(cert.Certificate[0])
+		require.NoError(t, err)
+		return leaf.Subject.CommonName
+	}
+	assert.Equal(t, "first", commonName())
+
+	writeCertificate(t, dir, "renewed")
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(certFile, later, later))
+	assert.Equal(t, "renewed", commonName())
+
+	// a half written certificate keeps the loaded one
+	require.NoError(t, ioutil.WriteFile(keyFile, []byte("partial"), 0600))
+	later = later.Add(time.Minute)
+	require.NoError(t, os.Chtimes(keyFile, later, later))
+	assert.Equal(t, "renewed", commonName())
+}
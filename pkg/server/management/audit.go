@@ -0,0 +1,72 @@
+package management
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/realityone/berrypost/pkg/audit"
+)
+
+const maxAuditLimit = 1000
+
+// SetAuditLog serves the audit records of the proxy on /management/api/audit.
+func SetAuditLog(in audit.Querier) Option {
+	return func(m *Management) {
+		m.auditLog = in
+	}
+}
+
+// parseAuditTime accepts RFC 3339 timestamps or a duration back from now,
+// e.g. 24h.
+func parseAuditTime(in string, now time.Time) (time.Time, error) {
+	if in == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(in); err == nil {
+		return now.Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, in)
+	if err != nil {
+		return time.Time{}, errors.Errorf("invalid time %q, expect RFC 3339 or a duration", in)
+	}
+	return t, nil
+}
+
+func (m Management) queryAudit(ctx *gin.Context) {
+	if m.auditLog == nil {
+		ctx.Error(errors.New("audit log is not enabled"))
+		return
+	}
+	now := time.Now()
+	since, err := parseAuditTime(ctx.Query("since"), now)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	until, err := parseAuditTime(ctx.Query("until"), now)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	limit, _ := strconv.Atoi(ctx.Query("limit"))
+	if limit > maxAuditLimit {
+		limit = maxAuditLimit
+	}
+	records, err := m.auditLog.Query(ctx, &audit.Query{
+		Subject:  ctx.Query("subject"),
+		Method:   ctx.Query("method"),
+		Target:   ctx.Query("target"),
+		GRPCCode: ctx.Query("code"),
+		Since:    since,
+		Until:    until,
+		Limit:    limit,
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, records)
+}
@@ -0,0 +1,378 @@
+package management
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/realityone/berrypost/pkg/metadata"
+	"github.com/realityone/berrypost/pkg/protohelper"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+type DescriptorComments struct {
+	Leading         string   `json:"leading,omitempty"`
+	Trailing        string   `json:"trailing,omitempty"`
+	LeadingDetached []string `json:"leading_detached,omitempty"`
+}
+
+type TypeReference struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+type FieldDetail struct {
+	Name           string             `json:"name"`
+	JSONName       string             `json:"json_name"`
+	Number         int32              `json:"number"`
+	Label          string             `json:"label"`
+	Type           string             `json:"type"`
+	TypeRef        *TypeReference     `json:"type_ref,omitempty"`
+	Default        string             `json:"default,omitempty"`
+	Oneof          string             `json:"oneof,omitempty"`
+	Proto3Optional bool               `json:"proto3_optional,omitempty"`
+	Deprecated     bool               `json:"deprecated,omitempty"`
+	Options        json.RawMessage    `json:"options,omitempty"`
+	Comments       DescriptorComments `json:"comments"`
+}
+
+type OneofDetail struct {
+	Name     string             `json:"name"`
+	Fields   []string           `json:"fields"`
+	Options  json.RawMessage    `json:"options,omitempty"`
+	Comments DescriptorComments `json:"comments"`
+}
+
+type EnumValueDetail struct {
+	Name       string             `json:"name"`
+	Number     int32              `json:"number"`
+	Deprecated bool               `json:"deprecated,omitempty"`
+	Options    json.RawMessage    `json:"options,omitempty"`
+	Comments   DescriptorComments `json:"comments"`
+}
+
+type MethodDetail struct {
+	Name            string             `json:"name"`
+	Input           TypeReference      `json:"input"`
+	Output          TypeReference      `json:"output"`
+	ClientStreaming bool               `json:"client_streaming"`
+	ServerStreaming bool               `json:"server_streaming"`
+	Deprecated      bool               `json:"deprecated,omitempty"`
+	Options         json.RawMessage    `json:"options,omitempty"`
+	Comments        DescriptorComments `json:"comments"`
+}
+
+type DescriptorDetail struct {
+	Kind       string             `json:"kind"`
+	FullName   string             `json:"full_name"`
+	File       TypeReference      `json:"file"`
+	Parent     *TypeReference     `json:"parent,omitempty"`
+	Deprecated bool               `json:"deprecated,omitempty"`
+	Options    json.RawMessage    `json:"options,omitempty"`
+	Comments   DescriptorComments `json:"comments"`
+
+	Fields   []*FieldDetail     `json:"fields,omitempty"`
+	Oneofs   []*OneofDetail     `json:"oneofs,omitempty"`
+	Messages []*TypeReference   `json:"messages,omitempty"`
+	Enums    []*TypeReference   `json:"enums,omitempty"`
+	Values   []*EnumValueDetail `json:"values,omitempty"`
+	Methods  []*MethodDetail    `json:"methods,omitempty"`
+}
+
+type FileDetail struct {
+	Path     string             `json:"path"`
+	Package  string             `json:"package"`
+	Syntax   string             `json:"syntax"`
+	Options  json.RawMessage    `json:"options,omitempty"`
+	Imports  []*TypeReference   `json:"imports"`
+	Services []*TypeReference   `json:"services"`
+	Messages []*TypeReference   `json:"messages"`
+	Enums    []*TypeReference   `json:"enums"`
+	Comments DescriptorComments `json:"comments"`
+}
+
+// descriptorBrowser renders descriptors with links that keep the proto
+// revision and proto path of the current request.
+type descriptorBrowser struct {
+	meta  metadata.Metadata
+	types *protoregistry.Types
+}
+
+func (b descriptorBrowser) query() string {
+	q := url.Values{}
+	if b.meta.ProtoRevision != "" {
+		q.Set("protoRevision", b.meta.ProtoRevision)
+	}
+	if b.meta.ProtoPath != "" {
+		q.Set("protoPath", b.meta.ProtoPath)
+	}
+	if len(q) == 0 {
+		return ""
+	}
+	return "?" + q.Encode()
+}
+
+func (b descriptorBrowser) descriptorRef(d protoreflect.Descriptor) *TypeReference {
+	return &TypeReference{
+		Name: string(d.FullName()),
+		URL:  fmt.Sprintf("/management/api/descriptors/%s%s", d.FullName(), b.query()),
+	}
+}
+
+func (b descriptorBrowser) fileRef(fd protoreflect.FileDescriptor) *TypeReference {
+	return &TypeReference{
+		Name: fd.Path(),
+		URL:  fmt.Sprintf("/management/api/files/%s%s", fd.Path(), b.query()),
+	}
+}
+
+func (b descriptorBrowser) options(d protoreflect.Descriptor) json.RawMessage {
+	out, err := protohelper.OptionsAsJSON(d, b.types)
+	if err != nil {
+		logrus.Warnf("Failed to render options of %q: %+v", d.FullName(), err)
+		return nil
+	}
+	return out
+}
+
+func descriptorComments(d protoreflect.Descriptor) DescriptorComments {
+	file := d.ParentFile()
+	if file == nil {
+		return DescriptorComments{}
+	}
+	loc := file.SourceLocations().ByDescriptor(d)
+	out := DescriptorComments{
+		Leading:  strings.TrimSpace(loc.LeadingComments),
+		Trailing: strings.TrimSpace(loc.TrailingComments),
+	}
+	for _, c := range loc.LeadingDetachedComments {
+		out.LeadingDetached = append(out.LeadingDetached, strings.TrimSpace(c))
+	}
+	return out
+}
+
+func fieldDefault(fd protoreflect.FieldDescriptor) string {
+	if !fd.HasDefault() {
+		return ""
+	}
+	if fd.Kind() == protoreflect.EnumKind {
+		if v := fd.DefaultEnumValue(); v != nil {
+			return string(v.Name())
+		}
+	}
+	if fd.Kind() == protoreflect.BytesKind {
+		return string(fd.Default().Bytes())
+	}
+	return fmt.Sprint(fd.Default().Interface())
+}
+
+func (b descriptorBrowser) field(fd protoreflect.FieldDescriptor) *FieldDetail {
+	out := &FieldDetail{
+		Name:           string(fd.Name()),
+		JSONName:       fd.JSONName(),
+		Number:         int32(fd.Number()),
+		Label:          fd.Cardinality().String(),
+		Type:           fieldTypeName(fd),
+		Default:        fieldDefault(fd),
+		Proto3Optional: fd.HasOptionalKeyword() && fd.Syntax() == protoreflect.Proto3,
+		Deprecated:     protohelper.IsDeprecated(fd),
+		Options:        b.options(fd),
+		Comments:       descriptorComments(fd),
+	}
+	if fd.IsMap() {
+		out.Label = "map"
+		fd = fd.MapValue()
+	}
+	switch {
+	case fd.Message() != nil:
+		out.TypeRef = b.descriptorRef(fd.Message())
+	case fd.Enum() != nil:
+		out.TypeRef = b.descriptorRef(fd.Enum())
+	}
+	return out
+}
+
+func (b descriptorBrowser) message(md protoreflect.MessageDescriptor, out *DescriptorDetail) {
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		f := fields.Get(i)
+		detail := b.field(f)
+		if od := f.ContainingOneof(); od != nil && !od.IsSynthetic() {
+			detail.Oneof = string(od.Name())
+		}
+		out.Fields = append(out.Fields, detail)
+	}
+	oneofs := md.Oneofs()
+	for i := 0; i < oneofs.Len(); i++ {
+		od := oneofs.Get(i)
+		if od.IsSynthetic() {
+			continue
+		}
+		detail := &OneofDetail{
+			Name:     string(od.Name()),
+			Options:  b.options(od),
+			Comments: descriptorComments(od),
+		}
+		members := od.Fields()
+		for j := 0; j < members.Len(); j++ {
+			detail.Fields = append(detail.Fields, string(members.Get(j).Name()))
+		}
+		out.Oneofs = append(out.Oneofs, detail)
+	}
+	messages := md.Messages()
+	for i := 0; i < messages.Len(); i++ {
+		if messages.Get(i).IsMapEntry() {
+			continue
+		}
+		out.Messages = append(out.Messages, b.descriptorRef(messages.Get(i)))
+	}
+	enums := md.Enums()
+	for i := 0; i < enums.Len(); i++ {
+		out.Enums = append(out.Enums, b.descriptorRef(enums.Get(i)))
+	}
+}
+
+func (b descriptorBrowser) enum(ed protoreflect.EnumDescriptor, out *DescriptorDetail) {
+	values := ed.Values()
+	for i := 0; i < values.Len(); i++ {
+		v := values.Get(i)
+		out.Values = append(out.Values, &EnumValueDetail{
+			Name:       string(v.Name()),
+			Number:     int32(v.Number()),
+			Deprecated: protohelper.IsDeprecated(v),
+			Options:    b.options(v),
+			Comments:   descriptorComments(v),
+		})
+	}
+}
+
+func (b descriptorBrowser) service(sd protoreflect.ServiceDescriptor, out *DescriptorDetail) {
+	methods := sd.Methods()
+	for i := 0; i < methods.Len(); i++ {
+		m := methods.Get(i)
+		out.Methods = append(out.Methods, &MethodDetail{
+			Name:            string(m.Name()),
+			Input:           *b.descriptorRef(m.Input()),
+			Output:          *b.descriptorRef(m.Output()),
+			ClientStreaming: m.IsStreamingClient(),
+			ServerStreaming: m.IsStreamingServer(),
+			Deprecated:      protohelper.IsDeprecated(m),
+			Options:         b.options(m),
+			Comments:        descriptorComments(m),
+		})
+	}
+}
+
+func (b descriptorBrowser) describe(d protoreflect.Descriptor) (*DescriptorDetail, error) {
+	out := &DescriptorDetail{
+		FullName:   string(d.FullName()),
+		File:       *b.fileRef(d.ParentFile()),
+		Deprecated: protohelper.IsDeprecated(d),
+		Options:    b.options(d),
+		Comments:   descriptorComments(d),
+	}
+	if parent, ok := d.Parent().(protoreflect.MessageDescriptor); ok {
+		out.Parent = b.descriptorRef(parent)
+	}
+	switch typed := d.(type) {
+	case protoreflect.MessageDescriptor:
+		out.Kind = "message"
+		b.message(typed, out)
+	case protoreflect.EnumDescriptor:
+		out.Kind = "enum"
+		b.enum(typed, out)
+	case protoreflect.ServiceDescriptor:
+		out.Kind = "service"
+		b.service(typed, out)
+	default:
+		return nil, errors.Errorf("%q is not a message, enum or service", d.FullName())
+	}
+	return out, nil
+}
+
+func (b descriptorBrowser) describeFile(fd protoreflect.FileDescriptor) *FileDetail {
+	out := &FileDetail{
+		Path:     fd.Path(),
+		Package:  string(fd.Package()),
+		Syntax:   fd.Syntax().String(),
+		Options:  b.options(fd),
+		Imports:  []*TypeReference{},
+		Services: []*TypeReference{},
+		Messages: []*TypeReference{},
+		Enums:    []*TypeReference{},
+		Comments: descriptorComments(fd),
+	}
+	imports := fd.Imports()
+	for i := 0; i < imports.Len(); i++ {
+		out.Imports = append(out.Imports, b.fileRef(imports.Get(i).FileDescriptor))
+	}
+	services := fd.Services()
+	for i := 0; i < services.Len(); i++ {
+		out.Services = append(out.Services, b.descriptorRef(services.Get(i)))
+	}
+	messages := fd.Messages()
+	for i := 0; i < messages.Len(); i++ {
+		out.Messages = append(out.Messages, b.descriptorRef(messages.Get(i)))
+	}
+	enums := fd.Enums()
+	for i := 0; i < enums.Len(); i++ {
+		out.Enums = append(out.Enums, b.descriptorRef(enums.Get(i)))
+	}
+	return out
+}
+
+func (m Management) findFileDescriptor(ctx context.Context, path string) (protoreflect.FileDescriptor, error) {
+	profiles, err := listProtoFileProfiles(ctx, m.resolveProtoManager(ctx))
+	if err != nil {
+		return nil, err
+	}
+	for _, profile := range profiles {
+		if profile.ProtoPackage == nil || profile.ProtoPackage.FileDescriptor == nil {
+			continue
+		}
+		if fd, err := profile.ProtoPackage.FileDescriptor.FindFileByPath(path); err == nil {
+			return fd, nil
+		}
+	}
+	return nil, errors.Errorf("Could not find proto file: %q", path)
+}
+
+func (m Management) describeDescriptor(ctx *gin.Context) {
+	d, err := m.protoStore().FindDescriptor(ctx, ctx.Param("full_name"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	meta, _ := metadata.FromContext(ctx)
+	browser := descriptorBrowser{
+		meta:  meta,
+		types: protohelper.NewDynamicTypes(d.ParentFile()),
+	}
+	detail, err := browser.describe(d)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, detail)
+}
+
+func (m Management) describeFile(ctx *gin.Context) {
+	fd, err := m.findFileDescriptor(ctx, strings.TrimPrefix(ctx.Param("path"), "/"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	meta, _ := metadata.FromContext(ctx)
+	browser := descriptorBrowser{
+		meta:  meta,
+		types: protohelper.NewDynamicTypes(fd),
+	}
+	ctx.JSON(http.StatusOK, browser.describeFile(fd))
+}
@@ -0,0 +1,93 @@
+package management
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/realityone/berrypost/pkg/metrics"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// ResolveProtoManager returns the proto manager on the given revision, the
+// empty revision stands for the current one.
+func ResolveProtoManager(ctx context.Context, pm ProtoManager, revision string) (ProtoManager, error) {
+	if revision == "" {
+		return pm, nil
+	}
+	defer metrics.ObserveLookup("proto_manager", "resolve_revision", time.Now())
+	rm, ok := pm.(RevisionManager)
+	if !ok {
+		return nil, errors.Errorf("proto manager %T does not support revision management", pm)
+	}
+	resolved, err := rm.ResolveRevision(ctx, revision)
+	if err != nil {
+		return nil, errors.Wrapf(err, "resolve revision: %q", revision)
+	}
+	return resolved, nil
+}
+
+func listProtoFileProfiles(ctx context.Context, pm ProtoManager) ([]*ProtoFileProfile, error) {
+	files, err := pm.ListProtoFiles(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "list proto files")
+	}
+	profiles := make([]*ProtoFileProfile, 0, len(files))
+	for _, f := range files {
+		profile, err := pm.GetProtoFile(ctx, &GetProtoFileRequest{
+			ImportPath: f.Meta.ImportPath,
+		})
+		if err != nil {
+			logrus.Warnf("Failed to get proto file by import path: %q: %+v", f.Meta.ImportPath, err)
+			continue
+		}
+		profiles = append(profiles, profile)
+	}
+	return profiles, nil
+}
+
+func findInProtoFile(ctx context.Context, pm ProtoManager, importPath string, name protoreflect.FullName) (protoreflect.Descriptor, bool) {
+	profile, err := pm.GetProtoFile(ctx, &GetProtoFileRequest{
+		ImportPath: importPath,
+	})
+	if err != nil {
+		logrus.Warnf("Failed to get proto file by import path: %q: %+v", importPath, err)
+		return nil, false
+	}
+	if profile.ProtoPackage == nil || profile.ProtoPackage.FileDescriptor == nil {
+		return nil, false
+	}
+	d, err := profile.ProtoPackage.FileDescriptor.FindDescriptorByName(name)
+	if err != nil {
+		return nil, false
+	}
+	return d, true
+}
+
+// FindDescriptorByName looks up a descriptor by its fully qualified name in
+// the proto file of the given import path first, and then in every proto file
+// known to the proto manager.
+func FindDescriptorByName(ctx context.Context, pm ProtoManager, importPath string, name protoreflect.FullName) (protoreflect.Descriptor, error) {
+	defer metrics.ObserveLookup("proto_manager", "find_descriptor", time.Now())
+	if importPath != "" {
+		if d, ok := findInProtoFile(ctx, pm, importPath, name); ok {
+			return d, nil
+		}
+	}
+
+	files, err := pm.ListProtoFiles(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "list proto files")
+	}
+	for _, f := range files {
+		if f.Meta.ImportPath == importPath {
+			continue
+		}
+		if d, ok := findInProtoFile(ctx, pm, f.Meta.ImportPath, name); ok {
+			return d, nil
+		}
+	}
+	return nil, errors.Wrapf(protoregistry.NotFound, "Could not find descriptor: %q", name)
+}
@@ -11,11 +11,15 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/golang/protobuf/jsonpb"
 	"github.com/pkg/errors"
+	"github.com/realityone/berrypost/pkg/audit"
 	"github.com/realityone/berrypost/pkg/metadata"
+	"github.com/realityone/berrypost/pkg/policy"
+	"github.com/realityone/berrypost/pkg/protohelper"
 	"github.com/realityone/berrypost/pkg/server"
+	"github.com/realityone/berrypost/pkg/server/contrib/csrf"
+	"github.com/realityone/berrypost/pkg/server/contrib/errorhandler"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/protobuf/reflect/protoreflect"
-	"google.golang.org/protobuf/types/dynamicpb"
 	"k8s.io/kube-openapi/pkg/util/sets"
 )
 
@@ -27,14 +31,36 @@ func SetProtoManager(in ProtoManager) Option {
 	}
 }
 
+// SetReadOnly flags the methods rejected by the read-only mode of the proxy.
+func SetReadOnly(in *policy.ReadOnly) Option {
+	return func(m *Management) {
+		m.readOnly = in
+	}
+}
+
+// SetTraceURL links the trace IDs of invocations on the invoke page, {trace_id}
+// in the URL is replaced, e.g. http://localhost:16686/trace/{trace_id}.
+func SetTraceURL(in string) Option {
+	return func(m *Management) {
+		m.traceURL = in
+	}
+}
+
 type Management struct {
-	server       *server.Server
-	protoManager ProtoManager
+	server        *server.Server
+	protoManager  ProtoManager
+	searchIndexes *searchIndexCache
+	constraints   *protohelper.ConstraintCheckers
+	readOnly      *policy.ReadOnly
+	auditLog      audit.Querier
+	traceURL      string
 }
 
 func New(opts ...Option) *Management {
 	m := &Management{
-		protoManager: defaultProtoManager{},
+		protoManager:  defaultProtoManager{},
+		searchIndexes: newSearchIndexCache(searchIndexCacheSize),
+		constraints:   protohelper.NewConstraintCheckers(constraintCheckersSize),
 	}
 	for _, opt := range opts {
 		opt(m)
@@ -42,6 +68,10 @@ func New(opts ...Option) *Management {
 	return m
 }
 
+func (m Management) ProtoManager() ProtoManager {
+	return m.protoManager
+}
+
 func (m Management) intro(ctx *gin.Context) {
 	introSchema := struct {
 		Name    string `json:"name"`
@@ -73,6 +103,19 @@ func (m Management) getPackage(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, packageProfile)
 }
 
+func (m Management) diffRevisions(ctx *gin.Context) {
+	diff, err := m.DiffRevisions(ctx, &DiffRequest{
+		From:        ctx.Query("from"),
+		To:          ctx.Query("to"),
+		PackageName: ctx.Query("package"),
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, diff)
+}
+
 func (m Management) listServiceAlias(ctx *gin.Context) {
 	alias, err := m.resolveProtoManager(ctx).ListServiceAlias(ctx)
 	if err != nil {
@@ -214,63 +257,79 @@ func (m Management) listKnownReferences(ctx context.Context) []*ReferenceItem {
 	return refs
 }
 
-func initializeMessageField(in *dynamicpb.Message) {
-	fields := in.Descriptor().Fields()
-	for i := 0; i < fields.Len(); i++ {
-		f := fields.Get(i)
-		if f.Kind() != protoreflect.MessageKind {
-			continue
-		}
-		if f.IsList() {
-			continue
-		}
-		in.Set(f, in.NewField(f))
+func invokePageURL(serviceIdentifier, protoRevision string) string {
+	dst := fmt.Sprintf("/management/invoke/%s", serviceIdentifier)
+	q := url.Values{}
+	if protoRevision != "" {
+		q.Set("protoRevision", protoRevision)
 	}
+	if len(q) > 0 {
+		dst = fmt.Sprintf("%s?%s", dst, q.Encode())
+	}
+	return dst
 }
 
-func (m Management) makeInvokePage(ctx context.Context, serviceIdentifier string) (*InvokePage, error) {
-	fileProfile, ok := m.findProtoFileByServiceIdentifier(ctx, serviceIdentifier)
-	if !ok {
-		return nil, errors.Errorf("Failed to find package profile from service identifier: %q", serviceIdentifier)
-	}
-	meta, _ := metadata.FromContext(ctx)
-	page := &InvokePage{
-		Meta:              m.server.Meta(),
-		ServiceIdentifier: serviceIdentifier,
-		PackageName:       fileProfile.ProtoPackage.Meta.ImportPath,
-		PreferTarget:      "",
-		ProtoFiles:        m.allProtoFiles(ctx),
-		InvokePageURLBuilder: func(serviceIdentifier, protoRevision string) string {
-			dst := fmt.Sprintf("/management/invoke/%s", serviceIdentifier)
-			q := url.Values{}
-			if protoRevision != "" {
-				q.Set("protoRevision", protoRevision)
-			}
-			if len(q) > 0 {
-				dst = fmt.Sprintf("%s?%s", dst, q.Encode())
-			}
-			return dst
-		},
-		Metadata:        meta,
-		KnownReferences: m.listKnownReferences(ctx),
-	}
+func defaultGRPCMetadata(meta metadata.Metadata, fileProfile *ProtoFileProfile) []*MetadataItem {
+	out := []*MetadataItem{}
 	if meta.ProtoRevision != "" {
-		page.DefaultGRPCMetadata = append(page.DefaultGRPCMetadata, &MetadataItem{
+		out = append(out, &MetadataItem{
 			Key:   metadata.ProtoRevisionGRPCMetadataKey,
 			Value: meta.ProtoRevision,
 		})
 	}
-	page.DefaultGRPCMetadata = append(page.DefaultGRPCMetadata, &MetadataItem{
+	out = append(out, &MetadataItem{
 		Key:   metadata.ProtoPathGRPCMetadataKey,
 		Value: fileProfile.ProtoPackage.Meta.ImportPath,
 	})
+	return out
+}
 
-	preferTarget, ok := fileProfile.Common.Annotation[AppBerrypostManagementInvokePreferTarget]
-	if ok {
-		page.PreferTarget = preferTarget
+func describeFields(md protoreflect.MessageDescriptor) []*FieldDoc {
+	fields := md.Fields()
+	out := make([]*FieldDoc, 0, fields.Len())
+	for i := 0; i < fields.Len(); i++ {
+		f := fields.Get(i)
+		out = append(out, &FieldDoc{
+			Name:       string(f.Name()),
+			JSONName:   f.JSONName(),
+			Type:       fieldTypeName(f),
+			Comment:    protohelper.LeadingComments(f),
+			Deprecated: protohelper.IsDeprecated(f),
+		})
 	}
+	return out
+}
 
-	page.Services = []*Service{}
+func describeMethod(s protoreflect.ServiceDescriptor, m protoreflect.MethodDescriptor) *Method {
+	pm := &Method{
+		Name:             string(m.Name()),
+		GRPCMethodName:   fmt.Sprintf("/%s/%s", s.FullName(), string(m.Name())),
+		ServiceMethod:    fmt.Sprintf("%s.%s", s.Name(), string(m.Name())),
+		InputType:        string(m.Input().FullName()),
+		OutputType:       string(m.Output().FullName()),
+		ClientStreaming:  m.IsStreamingClient(),
+		ServerStreaming:  m.IsStreamingServer(),
+		IdempotencyLevel: protohelper.IdempotencyLevel(m),
+		Deprecated:       protohelper.IsDeprecated(m),
+		Comment:          protohelper.LeadingComments(m),
+		Fields:           describeFields(m.Input()),
+	}
+	descMarshaler := jsonpb.Marshaler{
+		EmitDefaults: true,
+		Indent:       "    ",
+	}
+	dm, notes := protohelper.NewMessageTemplate(m.Input(), protohelper.DefaultTemplateMaxDepth)
+	pm.InputSchemaNotes = notes
+	inputSchema, err := descMarshaler.MarshalToString(dm)
+	if err != nil {
+		logrus.Warnf("Failed to marshal method: %q input type as string: %+v", m.FullName(), err)
+	}
+	pm.InputSchema = inputSchema
+	return pm
+}
+
+func describeServices(fileProfile *ProtoFileProfile) []*Service {
+	out := []*Service{}
 	for _, path := range fileProfile.ProtoPackage.Files {
 		fd, err := fileProfile.ProtoPackage.FileDescriptor.FindFileByPath(path)
 		if err != nil {
@@ -281,37 +340,83 @@ func (m Management) makeInvokePage(ctx context.Context, serviceIdentifier string
 		for i := 0; i < services.Len(); i++ {
 			s := services.Get(i)
 			ps := &Service{
-				Name: string(s.Name()),
+				Name:       string(s.Name()),
+				FullName:   string(s.FullName()),
+				Deprecated: protohelper.IsDeprecated(s),
+				Comment:    protohelper.LeadingComments(s),
 			}
 			methods := s.Methods()
 			ps.Methods = make([]*Method, 0, methods.Len())
 			for j := 0; j < methods.Len(); j++ {
-				m := methods.Get(j)
-				pm := &Method{
-					Name:           string(m.Name()),
-					GRPCMethodName: fmt.Sprintf("/%s/%s", s.FullName(), string(m.Name())),
-					ServiceMethod:  fmt.Sprintf("%s.%s", s.Name(), string(m.Name())),
-				}
-				descMarshaler := jsonpb.Marshaler{
-					EmitDefaults: true,
-					Indent:       "    ",
-				}
-				dm := dynamicpb.NewMessage(m.Input())
-				initializeMessageField(dm)
-				inputSchema, err := descMarshaler.MarshalToString(dm)
-				if err != nil {
-					logrus.Warn("Failed to marshal method: %q input type as string: %+v", m.FullName(), err)
-				}
-				pm.InputSchema = inputSchema
-				ps.Methods = append(ps.Methods, pm)
+				ps.Methods = append(ps.Methods, describeMethod(s, methods.Get(j)))
 			}
-			page.Services = append(page.Services, ps)
+			out = append(out, ps)
 		}
 	}
+	return out
+}
 
+func (m Management) makeInvokePage(ctx context.Context, serviceIdentifier string) (*InvokePage, error) {
+	fileProfile, ok := m.findProtoFileByServiceIdentifier(ctx, serviceIdentifier)
+	if !ok {
+		return nil, errors.Errorf("Failed to find package profile from service identifier: %q", serviceIdentifier)
+	}
+	meta, _ := metadata.FromContext(ctx)
+	page := &InvokePage{
+		Meta:                 m.server.Meta(),
+		ServiceIdentifier:    serviceIdentifier,
+		PackageName:          fileProfile.ProtoPackage.Meta.ImportPath,
+		PreferTarget:         "",
+		ProtoFiles:           m.allProtoFiles(ctx),
+		InvokePageURLBuilder: invokePageURL,
+		Metadata:             meta,
+		KnownReferences:      m.listKnownReferences(ctx),
+		DefaultGRPCMetadata:  defaultGRPCMetadata(meta, fileProfile),
+		Services:             describeServices(fileProfile),
+	}
+
+	preferTarget, ok := fileProfile.Common.Annotation[AppBerrypostManagementInvokePreferTarget]
+	if ok {
+		page.PreferTarget = preferTarget
+	}
+	page.ReadOnly = m.readOnly.AppliesTo(page.PreferTarget)
+	page.TraceURL = m.traceURL
+	m.flagUnsafeMethods(page.Services, page.PreferTarget)
 	return page, nil
 }
 
+func (m Management) makeServiceList(ctx context.Context, serviceIdentifier string) (*ServiceList, error) {
+	fileProfile, ok := m.findProtoFileByServiceIdentifier(ctx, serviceIdentifier)
+	if !ok {
+		return nil, errors.Errorf("Failed to find package profile from service identifier: %q", serviceIdentifier)
+	}
+	meta, _ := metadata.FromContext(ctx)
+	list := &ServiceList{
+		ServiceIdentifier:   serviceIdentifier,
+		ImportPath:          fileProfile.ProtoPackage.Meta.ImportPath,
+		PreferTarget:        fileProfile.Common.Annotation[AppBerrypostManagementInvokePreferTarget],
+		ProtoRevision:       meta.ProtoRevision,
+		DefaultGRPCMetadata: defaultGRPCMetadata(meta, fileProfile),
+		Services:            describeServices(fileProfile),
+	}
+	m.flagUnsafeMethods(list.Services, list.PreferTarget)
+	return list, nil
+}
+
+// flagUnsafeMethods marks the methods rejected by the read-only mode.
+func (m Management) flagUnsafeMethods(services []*Service, preferTarget string) {
+	if !m.readOnly.Configured() {
+		return
+	}
+	blocked := m.readOnly.AppliesTo(preferTarget)
+	for _, s := range services {
+		for _, pm := range s.Methods {
+			pm.Unsafe = !m.readOnly.IsSafe(s.FullName, pm.Name, pm.IdempotencyLevel)
+			pm.ReadOnlyBlocked = pm.Unsafe && blocked
+		}
+	}
+}
+
 func (m Management) firstServiceAlias(ctx context.Context) string {
 	serviceAlias, err := m.resolveProtoManager(ctx).ListServiceAlias(ctx)
 	if err != nil {
@@ -339,7 +444,7 @@ func (m Management) redirectToFirstService(ctx *gin.Context) {
 func (m Management) allProtoFiles(ctx context.Context) []*ProtoFileMeta {
 	files, err := m.resolveProtoManager(ctx).ListProtoFiles(ctx)
 	if err != nil {
-		logrus.Error("Failed to list proto files: %+v", err)
+		logrus.Errorf("Failed to list proto files: %+v", err)
 		return nil
 	}
 	return files
@@ -353,9 +458,21 @@ func (m Management) invoke(ctx *gin.Context) {
 		ctx.Error(err)
 		return
 	}
+	page.CSRFToken = csrf.Token(ctx)
 	ctx.HTML(http.StatusOK, "invoke.html", page)
 }
 
+func (m Management) listServices(ctx *gin.Context) {
+	serviceIdentifier := ctx.Param("service-identifier")
+	serviceIdentifier = strings.TrimPrefix(serviceIdentifier, "/")
+	services, err := m.makeServiceList(ctx, serviceIdentifier)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, services)
+}
+
 func (m Management) emptyInvoke(ctx *gin.Context) {
 	serviceIdentifier := "buf.bilibili.co/archive/service"
 	serviceIdentifier = strings.TrimPrefix(serviceIdentifier, "/")
@@ -364,6 +481,7 @@ func (m Management) emptyInvoke(ctx *gin.Context) {
 		ctx.Error(err)
 		return
 	}
+	page.CSRFToken = csrf.Token(ctx)
 	ctx.HTML(http.StatusOK, "invoke.html", page)
 }
 
@@ -383,11 +501,22 @@ func (m Management) Setup(s *server.Server) error {
 	r.GET("/invoke", m.emptyInvoke)
 	r.GET("/invoke/*service-identifier", m.invoke)
 
-	rAPI := s.Group("/management/api", m.prepareBuiltinMetadata)
+	rAPI := s.Group("/management/api", errorhandler.JSONErrorHandler(), m.prepareBuiltinMetadata)
 	rAPI.GET("/_intro", m.intro)
 	rAPI.GET("/packages", m.listPackages)
 	rAPI.GET("/packages/:package_name", m.getPackage)
 	rAPI.GET("/service-alias", m.listServiceAlias)
+	rAPI.GET("/diff", m.diffRevisions)
+	rAPI.GET("/openapi.json", m.openAPI)
+	rAPI.GET("/search", m.search)
+	rAPI.GET("/services/*service-identifier", m.listServices)
+	rAPI.GET("/descriptors/:full_name", m.describeDescriptor)
+	rAPI.GET("/files/*path", m.describeFile)
+	rAPI.GET("/schema/messages/:message_name", m.messageSchema)
+	rAPI.GET("/schema/methods/:service/:method/:direction", m.methodSchema)
+	rAPI.POST("/validate/:service/:method", m.validate)
+	rAPI.GET("/constraints/:service/:method", m.listConstraints)
+	rAPI.GET("/audit", m.queryAudit)
 	return nil
 }
 
@@ -398,3 +527,35 @@ func (m Management) Name() string {
 func (m Management) Meta() map[string]string {
 	return nil
 }
+
+// Mandatory is true, berrypost is of no use without its protos.
+func (m Management) Mandatory() bool {
+	return true
+}
+
+// Start starts the proto manager if it loads or watches protos.
+func (m Management) Start(ctx context.Context) error {
+	if starter, ok := m.protoManager.(server.Starter); ok {
+		return starter.Start(ctx)
+	}
+	return nil
+}
+
+func (m Management) Stop(ctx context.Context) error {
+	if stopper, ok := m.protoManager.(server.Stopper); ok {
+		return stopper.Stop(ctx)
+	}
+	return nil
+}
+
+// Ready reports whether the protos are compiled, by the proto manager if it
+// knows, by listing the proto files otherwise.
+func (m Management) Ready(ctx context.Context) error {
+	if rc, ok := m.protoManager.(server.ReadinessChecker); ok {
+		return rc.Ready(ctx)
+	}
+	if _, err := m.protoManager.ListProtoFiles(ctx); err != nil {
+		return errors.Wrap(err, "list proto files")
+	}
+	return nil
+}
@@ -0,0 +1,262 @@
+package management
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/realityone/berrypost/pkg/metadata"
+	"github.com/realityone/berrypost/pkg/protohelper"
+	"github.com/realityone/berrypost/pkg/server/contrib/csrf"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	openAPIVersion       = "3.1.0"
+	openAPISchemasPrefix = "#/components/schemas/"
+)
+
+type OpenAPIDocument struct {
+	OpenAPI    string                      `json:"openapi"`
+	Info       OpenAPIInfo                 `json:"info"`
+	Paths      map[string]*OpenAPIPathItem `json:"paths"`
+	Components OpenAPIComponents           `json:"components"`
+}
+
+type OpenAPIInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type OpenAPIPathItem struct {
+	Post *OpenAPIOperation `json:"post,omitempty"`
+}
+
+type OpenAPIOperation struct {
+	OperationID string                      `json:"operationId"`
+	Summary     string                      `json:"summary,omitempty"`
+	Description string                      `json:"description,omitempty"`
+	Tags        []string                    `json:"tags,omitempty"`
+	Deprecated  bool                        `json:"deprecated,omitempty"`
+	Parameters  []*OpenAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *OpenAPIRequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*OpenAPIResponse `json:"responses"`
+}
+
+type OpenAPIParameter struct {
+	Name        string                  `json:"name"`
+	In          string                  `json:"in"`
+	Description string                  `json:"description,omitempty"`
+	Required    bool                    `json:"required,omitempty"`
+	Schema      *protohelper.JSONSchema `json:"schema,omitempty"`
+}
+
+type OpenAPIRequestBody struct {
+	Required bool                         `json:"required,omitempty"`
+	Content  map[string]*OpenAPIMediaType `json:"content"`
+}
+
+type OpenAPIMediaType struct {
+	Schema *protohelper.JSONSchema `json:"schema"`
+}
+
+type OpenAPIResponse struct {
+	Description string                       `json:"description"`
+	Content     map[string]*OpenAPIMediaType `json:"content,omitempty"`
+}
+
+type OpenAPIComponents struct {
+	Schemas map[string]*protohelper.JSONSchema `json:"schemas"`
+}
+
+func openAPIErrorSchema() *protohelper.JSONSchema {
+	return &protohelper.JSONSchema{
+		Type: "object",
+		Properties: map[string]*protohelper.JSONSchema{
+			"code":    {Type: "string"},
+			"message": {Type: "string"},
+			"detail":  {},
+		},
+	}
+}
+
+// payloadContent lists the media types negotiated by /invoke for a message,
+// the wire formats are described as opaque strings.
+func payloadContent(schema *protohelper.JSONSchema) map[string]*OpenAPIMediaType {
+	return map[string]*OpenAPIMediaType{
+		"application/json":                        {Schema: schema},
+		"application/x-protobuf":                  {Schema: &protohelper.JSONSchema{Type: "string", Format: "binary"}},
+		"application/x-protobuf; encoding=base64": {Schema: &protohelper.JSONSchema{Type: "string", Format: "byte"}},
+		"application/x-protobuf; encoding=hex":    {Schema: &protohelper.JSONSchema{Type: "string"}},
+		"text/x-protobuf":                         {Schema: &protohelper.JSONSchema{Type: "string"}},
+	}
+}
+
+func stringParameterSchema(defaultValue string) *protohelper.JSONSchema {
+	schema := &protohelper.JSONSchema{Type: "string"}
+	if defaultValue != "" {
+		schema.Default = defaultValue
+	}
+	return schema
+}
+
+var replyFormatParameters = []struct {
+	name        string
+	description string
+	schema      *protohelper.JSONSchema
+}{
+	{"X-Berrypost-Emit-Defaults", "Render fields with zero values.", &protohelper.JSONSchema{Type: "boolean"}},
+	{"X-Berrypost-Orig-Name", "Use proto field names instead of JSON names.", &protohelper.JSONSchema{Type: "boolean"}},
+	{"X-Berrypost-Enums-As-Ints", "Render enums as numbers.", &protohelper.JSONSchema{Type: "boolean"}},
+	{"X-Berrypost-Int64-As-Number", "Render 64-bit integers as numbers instead of strings.", &protohelper.JSONSchema{Type: "boolean"}},
+	{"X-Berrypost-Indent", "Spaces per indent level, or compact.", &protohelper.JSONSchema{Type: "string", Default: "4"}},
+}
+
+func invokeParameters(importPath, protoRevision string) []*OpenAPIParameter {
+	params := []*OpenAPIParameter{
+		{
+			Name:        "X-Berrypost-Target",
+			In:          "header",
+			Description: "Backend to dial, e.g. tcp://127.0.0.1:9000. Resolved by the configured resolvers if omitted.",
+			Schema:      &protohelper.JSONSchema{Type: "string"},
+		},
+		{
+			Name:        http.CanonicalHeaderKey("X-Berrypost-Md-" + metadata.ProtoPathGRPCMetadataKey),
+			In:          "header",
+			Description: "Import path of the proto file describing this method.",
+			Schema:      stringParameterSchema(importPath),
+		},
+		{
+			Name:        http.CanonicalHeaderKey("X-Berrypost-Md-" + metadata.ProtoRevisionGRPCMetadataKey),
+			In:          "header",
+			Description: "Proto revision used to encode the request and decode the reply.",
+			Schema:      stringParameterSchema(protoRevision),
+		},
+		{
+			Name:        "X-Berrypost-Lenient",
+			In:          "header",
+			Description: "Drop unknown request fields instead of rejecting the request.",
+			Schema:      &protohelper.JSONSchema{Type: "boolean"},
+		},
+		{
+			Name:        "X-Berrypost-Skip-Constraints",
+			In:          "header",
+			Description: "Send the request even if it violates protovalidate or PGV field constraints.",
+			Schema:      &protohelper.JSONSchema{Type: "boolean"},
+		},
+		{
+			Name:        "X-Berrypost-Raw",
+			In:          "header",
+			Description: "Invoke without a schema, the request is field number keyed JSON or the wire format and the reply is decoded from the wire format.",
+			Schema:      &protohelper.JSONSchema{Type: "boolean"},
+		},
+		{
+			Name:        csrf.HeaderName,
+			In:          "header",
+			Description: "CSRF token of the browser, required if the berrypost_csrf cookie is sent.",
+			Schema:      &protohelper.JSONSchema{Type: "string"},
+		},
+	}
+	for _, p := range replyFormatParameters {
+		params = append(params, &OpenAPIParameter{
+			Name:        p.name,
+			In:          "header",
+			Description: p.description,
+			Schema:      p.schema,
+		})
+	}
+	return params
+}
+
+func (m Management) makeOpenAPIDocument(ctx context.Context) (*OpenAPIDocument, error) {
+	meta, _ := metadata.FromContext(ctx)
+	version := meta.ProtoRevision
+	if version == "" {
+		version = "latest"
+	}
+	doc := &OpenAPIDocument{
+		OpenAPI: openAPIVersion,
+		Info: OpenAPIInfo{
+			Title:       m.server.Meta().Name,
+			Description: m.server.Meta().Description + " Any X-Berrypost-Md-<key> request header is forwarded to the backend as gRPC metadata <key>.",
+			Version:     version,
+		},
+		Paths: map[string]*OpenAPIPathItem{},
+	}
+	g := protohelper.NewJSONSchemaGenerator(openAPISchemasPrefix)
+
+	profiles, err := listProtoFileProfiles(ctx, m.resolveProtoManager(ctx))
+	if err != nil {
+		return nil, err
+	}
+	for _, profile := range profiles {
+		if profile.ProtoPackage == nil {
+			continue
+		}
+		importPath := profile.ProtoPackage.Meta.ImportPath
+		for _, fd := range fileDescriptorsOf([]*ProtoFileProfile{profile}) {
+			services := fd.Services()
+			for i := 0; i < services.Len(); i++ {
+				m.addOpenAPIService(doc, g, services.Get(i), importPath, meta.ProtoRevision)
+			}
+		}
+	}
+	doc.Components.Schemas = g.Defs
+	return doc, nil
+}
+
+func (m Management) addOpenAPIService(doc *OpenAPIDocument, g *protohelper.JSONSchemaGenerator, sd protoreflect.ServiceDescriptor, importPath, protoRevision string) {
+	methods := sd.Methods()
+	for i := 0; i < methods.Len(); i++ {
+		md := methods.Get(i)
+		if md.IsStreamingClient() || md.IsStreamingServer() {
+			logrus.Debugf("Skipping streaming method in OpenAPI document: %q", md.FullName())
+			continue
+		}
+		path := fmt.Sprintf("/invoke/%s/%s", sd.FullName(), md.Name())
+		if _, ok := doc.Paths[path]; ok {
+			continue
+		}
+		comments := protohelper.LeadingComments(md)
+		summary := strings.SplitN(comments, "\n", 2)[0]
+		doc.Paths[path] = &OpenAPIPathItem{
+			Post: &OpenAPIOperation{
+				OperationID: fmt.Sprintf("%s.%s", sd.FullName(), md.Name()),
+				Summary:     summary,
+				Description: comments,
+				Tags:        []string{string(sd.FullName())},
+				Deprecated:  protohelper.IsDeprecated(md),
+				Parameters:  invokeParameters(importPath, protoRevision),
+				RequestBody: &OpenAPIRequestBody{
+					Required: true,
+					Content:  payloadContent(g.MessageSchema(md.Input())),
+				},
+				Responses: map[string]*OpenAPIResponse{
+					"200": {
+						Description: "Reply of the backend. Response metadata is returned as X-Berrypost-Md-* and X-Berrypost-Md-Trailer-* headers.",
+						Content:     payloadContent(g.MessageSchema(md.Output())),
+					},
+					"400": {
+						Description: "Berrypost failed to invoke the backend or the backend returned an error.",
+						Content: map[string]*OpenAPIMediaType{
+							"application/json": {Schema: openAPIErrorSchema()},
+						},
+					},
+				},
+			},
+		}
+	}
+}
+
+func (m Management) openAPI(ctx *gin.Context) {
+	doc, err := m.makeOpenAPIDocument(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, doc)
+}
@@ -0,0 +1,184 @@
+package management
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/realityone/berrypost/pkg/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const openAPIProto = `
+name: "orders.proto" package: "shop" syntax: "proto3"
+message_type {
+  name: "Order"
+  field { name: "order_id" number: 1 type: TYPE_INT64 label: LABEL_OPTIONAL json_name: "orderId" }
+  field { name: "state" number: 2 type: TYPE_ENUM type_name: ".shop.State" label: LABEL_OPTIONAL json_name: "state" }
+  field { name: "items" number: 3 type: TYPE_MESSAGE type_name: ".shop.Order.Item" label: LABEL_REPEATED json_name: "items" }
+  field { name: "parent" number: 4 type: TYPE_MESSAGE type_name: ".shop.Order" label: LABEL_OPTIONAL json_name: "parent" }
+  nested_type {
+    name: "Item"
+    field { name: "sku" number: 1 type: TYPE_STRING label: LABEL_OPTIONAL json_name: "sku" }
+  }
+}
+message_type {
+  name: "GetOrderRequest"
+  field { name: "order_id" number: 1 type: TYPE_INT64 label: LABEL_OPTIONAL json_name: "orderId" }
+}
+enum_type {
+  name: "State"
+  value { name: "STATE_UNKNOWN" number: 0 }
+  value { name: "STATE_PAID" number: 1 }
+}
+service {
+  name: "Orders"
+  method { name: "GetOrder" input_type: ".shop.GetOrderRequest" output_type: ".shop.Order" options { deprecated: true } }
+  method { name: "WatchOrders" input_type: ".shop.GetOrderRequest" output_type: ".shop.Order" server_streaming: true }
+  method { name: "ImportOrders" input_type: ".shop.Order" output_type: ".shop.GetOrderRequest" client_streaming: true }
+}
+`
+
+var (
+	openAPIVersionPattern   = regexp.MustCompile(`^3\.1\.\d+$`)
+	openAPIComponentPattern = regexp.MustCompile(`^[a-zA-Z0-9.\-_]+$`)
+	jsonSchemaTypes         = map[string]bool{"null": true, "boolean": true, "object": true, "array": true, "number": true, "integer": true, "string": true}
+	openAPIParameterIns     = map[string]bool{"query": true, "header": true, "path": true, "cookie": true}
+)
+
+// validateOpenAPIDocument checks the constraints of the OpenAPI 3.1
+// specification the document is subject to.
+func validateOpenAPIDocument(t *testing.T, doc map[string]interface{}) {
+	assert.Regexp(t, openAPIVersionPattern, doc["openapi"])
+	info, _ := doc["info"].(map[string]interface{})
+	require.NotNil(t, info, "info is required")
+	assert.NotEmpty(t, info["title"], "info.title is required")
+	assert.NotEmpty(t, info["version"], "info.version is required")
+
+	components, _ := doc["components"].(map[string]interface{})
+	schemas, _ := components["schemas"].(map[string]interface{})
+	for name, schema := range schemas {
+		assert.Regexp(t, openAPIComponentPattern, name)
+		validateOpenAPISchema(t, schemas, "#/components/schemas/"+name, schema)
+	}
+
+	paths, _ := doc["paths"].(map[string]interface{})
+	operationIDs := map[string]bool{}
+	for path, item := range paths {
+		assert.True(t, strings.HasPrefix(path, "/"), "path %q must start with /", path)
+		for method, op := range item.(map[string]interface{}) {
+			where := method + " " + path
+			operation := op.(map[string]interface{})
+			id, _ := operation["operationId"].(string)
+			assert.False(t, operationIDs[id], "%s: duplicated operationId %q", where, id)
+			operationIDs[id] = true
+
+			seen := map[string]bool{}
+			params, _ := operation["parameters"].([]interface{})
+			for _, p := range params {
+				param := p.(map[string]interface{})
+				name, _ := param["name"].(string)
+				in, _ := param["in"].(string)
+				assert.NotEmpty(t, name, where)
+				assert.True(t, openAPIParameterIns[in], "%s: parameter %q is in %q", where, name, in)
+				assert.False(t, seen[in+" "+strings.ToLower(name)], "%s: duplicated parameter %q", where, name)
+				seen[in+" "+strings.ToLower(name)] = true
+				validateOpenAPISchema(t, schemas, where+" "+name, param["schema"])
+			}
+			if body, ok := operation["requestBody"].(map[string]interface{}); ok {
+				for mediaType, content := range body["content"].(map[string]interface{}) {
+					validateOpenAPISchema(t, schemas, where+" "+mediaType, content.(map[string]interface{})["schema"])
+				}
+			}
+
+			responses, _ := operation["responses"].(map[string]interface{})
+			assert.NotEmpty(t, responses, "%s: responses are required", where)
+			for code, r := range responses {
+				response := r.(map[string]interface{})
+				assert.NotEmpty(t, response["description"], "%s %s: description is required", where, code)
+				content, _ := response["content"].(map[string]interface{})
+				for mediaType, c := range content {
+					validateOpenAPISchema(t, schemas, where+" "+code+" "+mediaType, c.(map[string]interface{})["schema"])
+				}
+			}
+		}
+	}
+}
+
+// validateOpenAPISchema checks the types of a schema and that its references
+// resolve against the component schemas.
+func validateOpenAPISchema(t *testing.T, schemas map[string]interface{}, where string, in interface{}) {
+	switch v := in.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			switch key {
+			case "$ref":
+				ref, _ := value.(string)
+				require.True(t, strings.HasPrefix(ref, "#/components/schemas/"), "%s: $ref %q", where, ref)
+				_, ok := schemas[strings.TrimPrefix(ref, "#/components/schemas/")]
+				assert.True(t, ok, "%s: $ref %q does not resolve", where, ref)
+			case "type":
+				types, ok := value.([]interface{})
+				if !ok {
+					types = []interface{}{value}
+				}
+				for _, typ := range types {
+					name, _ := typ.(string)
+					assert.True(t, jsonSchemaTypes[name], "%s: unknown type %v", where, typ)
+				}
+			case "enum", "default", "const", "examples":
+			default:
+				validateOpenAPISchema(t, schemas, where+"/"+key, value)
+			}
+		}
+	case []interface{}:
+		for _, value := range v {
+			validateOpenAPISchema(t, schemas, where, value)
+		}
+	}
+}
+
+func TestOpenAPIDocument(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	pm := &staticProtoManager{files: []protoreflect.FileDescriptor{
+		mustFileDescriptor(t, openAPIProto),
+		mustFileDescriptor(t, searchProto),
+	}}
+	s := server.New(server.SetGinMiddlewares(nil))
+	require.NoError(t, New(SetProtoManager(pm)).Setup(s))
+
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/management/api/openapi.json", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	doc := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+	validateOpenAPIDocument(t, doc)
+
+	paths := []string{}
+	for path := range doc["paths"].(map[string]interface{}) {
+		paths = append(paths, path)
+	}
+	// streaming methods can not be invoked by a single request
+	assert.ElementsMatch(t, []string{
+		"/invoke/shop.Orders/GetOrder",
+		"/invoke/demo.Users/GetUser",
+		"/invoke/demo.Users/ListUsers",
+	}, paths)
+
+	typed := &OpenAPIDocument{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), typed))
+	getOrder := typed.Paths["/invoke/shop.Orders/GetOrder"].Post
+	assert.True(t, getOrder.Deprecated)
+	assert.Equal(t, "shop.Orders.GetOrder", getOrder.OperationID)
+	assert.Equal(t, openAPISchemasPrefix+"shop.GetOrderRequest", getOrder.RequestBody.Content["application/json"].Schema.Ref)
+	assert.Equal(t, openAPISchemasPrefix+"shop.Order", getOrder.Responses["200"].Content["application/json"].Schema.Ref)
+	for _, name := range []string{"shop.Order", "shop.Order.Item", "shop.GetOrderRequest", "demo.User"} {
+		assert.Contains(t, typed.Components.Schemas, name)
+	}
+}
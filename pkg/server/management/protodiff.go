@@ -0,0 +1,546 @@
+package management
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+type ChangeSeverity string
+
+const (
+	SeverityWireBreaking ChangeSeverity = "wire-breaking"
+	SeverityJSONBreaking ChangeSeverity = "json-breaking"
+	SeveritySafe         ChangeSeverity = "safe"
+)
+
+type ProtoChange struct {
+	Severity ChangeSeverity `json:"severity"`
+	Kind     string         `json:"kind"`
+	Element  string         `json:"element"`
+	Message  string         `json:"message"`
+}
+
+type ProtoDiff struct {
+	From    string                 `json:"from"`
+	To      string                 `json:"to"`
+	Package string                 `json:"package"`
+	Summary map[ChangeSeverity]int `json:"summary"`
+	Changes []*ProtoChange         `json:"changes"`
+}
+
+type DiffRequest struct {
+	From        string
+	To          string
+	PackageName string
+}
+
+type descriptorIndex struct {
+	services map[protoreflect.FullName]protoreflect.ServiceDescriptor
+	messages map[protoreflect.FullName]protoreflect.MessageDescriptor
+	enums    map[protoreflect.FullName]protoreflect.EnumDescriptor
+}
+
+func newDescriptorIndex(files []protoreflect.FileDescriptor) *descriptorIndex {
+	idx := &descriptorIndex{
+		services: map[protoreflect.FullName]protoreflect.ServiceDescriptor{},
+		messages: map[protoreflect.FullName]protoreflect.MessageDescriptor{},
+		enums:    map[protoreflect.FullName]protoreflect.EnumDescriptor{},
+	}
+	var walkEnums func(protoreflect.EnumDescriptors)
+	walkEnums = func(enums protoreflect.EnumDescriptors) {
+		for i := 0; i < enums.Len(); i++ {
+			e := enums.Get(i)
+			idx.enums[e.FullName()] = e
+		}
+	}
+	var walkMessages func(protoreflect.MessageDescriptors)
+	walkMessages = func(messages protoreflect.MessageDescriptors) {
+		for i := 0; i < messages.Len(); i++ {
+			md := messages.Get(i)
+			if md.IsMapEntry() {
+				continue
+			}
+			idx.messages[md.FullName()] = md
+			walkEnums(md.Enums())
+			walkMessages(md.Messages())
+		}
+	}
+	for _, fd := range files {
+		services := fd.Services()
+		for i := 0; i < services.Len(); i++ {
+			s := services.Get(i)
+			idx.services[s.FullName()] = s
+		}
+		walkEnums(fd.Enums())
+		walkMessages(fd.Messages())
+	}
+	return idx
+}
+
+type changeCollector struct {
+	changes []*ProtoChange
+}
+
+func (c *changeCollector) add(severity ChangeSeverity, kind string, element protoreflect.FullName, format string, args ...interface{}) {
+	c.changes = append(c.changes, &ProtoChange{
+		Severity: severity,
+		Kind:     kind,
+		Element:  string(element),
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func sortedNames(in ...map[protoreflect.FullName]struct{}) []protoreflect.FullName {
+	all := map[protoreflect.FullName]struct{}{}
+	for _, m := range in {
+		for k := range m {
+			all[k] = struct{}{}
+		}
+	}
+	out := make([]protoreflect.FullName, 0, len(all))
+	for k := range all {
+		out = append(out, k)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+func serviceNames(in map[protoreflect.FullName]protoreflect.ServiceDescriptor) map[protoreflect.FullName]struct{} {
+	out := make(map[protoreflect.FullName]struct{}, len(in))
+	for k := range in {
+		out[k] = struct{}{}
+	}
+	return out
+}
+
+func messageNames(in map[protoreflect.FullName]protoreflect.MessageDescriptor) map[protoreflect.FullName]struct{} {
+	out := make(map[protoreflect.FullName]struct{}, len(in))
+	for k := range in {
+		out[k] = struct{}{}
+	}
+	return out
+}
+
+func enumNames(in map[protoreflect.FullName]protoreflect.EnumDescriptor) map[protoreflect.FullName]struct{} {
+	out := make(map[protoreflect.FullName]struct{}, len(in))
+	for k := range in {
+		out[k] = struct{}{}
+	}
+	return out
+}
+
+// DiffFileDescriptors compares two sets of file descriptors and classifies
+// every difference by how it affects existing clients.
+func DiffFileDescriptors(from, to []protoreflect.FileDescriptor) []*ProtoChange {
+	oldIdx, newIdx := newDescriptorIndex(from), newDescriptorIndex(to)
+	c := &changeCollector{}
+
+	for _, name := range sortedNames(serviceNames(oldIdx.services), serviceNames(newIdx.services)) {
+		oldSvc, oldOK := oldIdx.services[name]
+		newSvc, newOK := newIdx.services[name]
+		switch {
+		case !newOK:
+			c.add(SeverityWireBreaking, "service_removed", name, "service %q was removed", name)
+		case !oldOK:
+			c.add(SeveritySafe, "service_added", name, "service %q was added", name)
+		default:
+			diffService(c, oldSvc, newSvc)
+		}
+	}
+	for _, name := range sortedNames(messageNames(oldIdx.messages), messageNames(newIdx.messages)) {
+		om, oldOK := oldIdx.messages[name]
+		nm, newOK := newIdx.messages[name]
+		switch {
+		case !newOK:
+			c.add(SeverityWireBreaking, "message_removed", name, "message %q was removed", name)
+		case !oldOK:
+			c.add(SeveritySafe, "message_added", name, "message %q was added", name)
+		default:
+			diffMessage(c, om, nm)
+		}
+	}
+	for _, name := range sortedNames(enumNames(oldIdx.enums), enumNames(newIdx.enums)) {
+		oe, oldOK := oldIdx.enums[name]
+		ne, newOK := newIdx.enums[name]
+		switch {
+		case !newOK:
+			c.add(SeverityWireBreaking, "enum_removed", name, "enum %q was removed", name)
+		case !oldOK:
+			c.add(SeveritySafe, "enum_added", name, "enum %q was added", name)
+		default:
+			diffEnum(c, oe, ne)
+		}
+	}
+	return c.changes
+}
+
+func diffService(c *changeCollector, oldSvc, newSvc protoreflect.ServiceDescriptor) {
+	oldMethods, newMethods := oldSvc.Methods(), newSvc.Methods()
+	for i := 0; i < oldMethods.Len(); i++ {
+		om := oldMethods.Get(i)
+		nm := newMethods.ByName(om.Name())
+		if nm == nil {
+			c.add(SeverityWireBreaking, "method_removed", om.FullName(), "method %q was removed", om.FullName())
+			continue
+		}
+		if om.Input().FullName() != nm.Input().FullName() {
+			c.add(SeverityWireBreaking, "method_request_type_changed", om.FullName(),
+				"request type changed from %q to %q", om.Input().FullName(), nm.Input().FullName())
+		}
+		if om.Output().FullName() != nm.Output().FullName() {
+			c.add(SeverityWireBreaking, "method_response_type_changed", om.FullName(),
+				"response type changed from %q to %q", om.Output().FullName(), nm.Output().FullName())
+		}
+		if om.IsStreamingClient() != nm.IsStreamingClient() || om.IsStreamingServer() != nm.IsStreamingServer() {
+			c.add(SeverityWireBreaking, "method_streaming_changed", om.FullName(),
+				"streaming changed from %s to %s", streamingKind(om), streamingKind(nm))
+		}
+	}
+	for i := 0; i < newMethods.Len(); i++ {
+		nm := newMethods.Get(i)
+		if oldMethods.ByName(nm.Name()) == nil {
+			c.add(SeveritySafe, "method_added", nm.FullName(), "method %q was added", nm.FullName())
+		}
+	}
+}
+
+func streamingKind(m protoreflect.MethodDescriptor) string {
+	switch {
+	case m.IsStreamingClient() && m.IsStreamingServer():
+		return "bidi-streaming"
+	case m.IsStreamingClient():
+		return "client-streaming"
+	case m.IsStreamingServer():
+		return "server-streaming"
+	default:
+		return "unary"
+	}
+}
+
+func diffMessage(c *changeCollector, om, nm protoreflect.MessageDescriptor) {
+	oldFields, newFields := om.Fields(), nm.Fields()
+	for i := 0; i < oldFields.Len(); i++ {
+		of := oldFields.Get(i)
+		nf := newFields.ByNumber(of.Number())
+		if nf == nil {
+			if moved := newFields.ByName(of.Name()); moved != nil {
+				c.add(SeverityWireBreaking, "field_number_changed", of.FullName(),
+					"field number changed from %d to %d", of.Number(), moved.Number())
+				continue
+			}
+			if nm.ReservedRanges().Has(of.Number()) {
+				c.add(SeverityJSONBreaking, "field_removed", of.FullName(),
+					"field %q (%d) was removed and its number is reserved", of.Name(), of.Number())
+				continue
+			}
+			c.add(SeverityWireBreaking, "field_removed", of.FullName(),
+				"field %q (%d) was removed without reserving its number", of.Name(), of.Number())
+			continue
+		}
+		diffField(c, of, nf)
+	}
+	for i := 0; i < newFields.Len(); i++ {
+		nf := newFields.Get(i)
+		if oldFields.ByNumber(nf.Number()) != nil {
+			continue
+		}
+		if oldFields.ByName(nf.Name()) != nil {
+			// already reported as a number change
+			continue
+		}
+		if nf.Cardinality() == protoreflect.Required {
+			c.add(SeverityWireBreaking, "field_added", nf.FullName(), "required field %q (%d) was added", nf.Name(), nf.Number())
+			continue
+		}
+		c.add(SeveritySafe, "field_added", nf.FullName(), "field %q (%d) was added", nf.Name(), nf.Number())
+	}
+	diffOneofs(c, om, nm)
+}
+
+// realOneof returns the oneof containing fd, ignoring the synthetic oneofs of
+// proto3 optional fields.
+func realOneof(fd protoreflect.FieldDescriptor) protoreflect.OneofDescriptor {
+	if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+		return od
+	}
+	return nil
+}
+
+func oneofNumbers(od protoreflect.OneofDescriptor) []protoreflect.FieldNumber {
+	fields := od.Fields()
+	out := make([]protoreflect.FieldNumber, 0, fields.Len())
+	for i := 0; i < fields.Len(); i++ {
+		out = append(out, fields.Get(i).Number())
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+// sameOneofMembers reports whether the oneofs hold the same field numbers,
+// that is one is a rename of the other.
+func sameOneofMembers(a, b protoreflect.OneofDescriptor) bool {
+	an, bn := oneofNumbers(a), oneofNumbers(b)
+	if len(an) != len(bn) {
+		return false
+	}
+	for i := range an {
+		if an[i] != bn[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// diffOneofs reports the oneofs renamed without changing their members, the
+// membership changes of fields are reported by diffField.
+func diffOneofs(c *changeCollector, om, nm protoreflect.MessageDescriptor) {
+	oldOneofs, newOneofs := om.Oneofs(), nm.Oneofs()
+	for i := 0; i < oldOneofs.Len(); i++ {
+		oo := oldOneofs.Get(i)
+		if oo.IsSynthetic() || newOneofs.ByName(oo.Name()) != nil {
+			continue
+		}
+		for j := 0; j < newOneofs.Len(); j++ {
+			no := newOneofs.Get(j)
+			if !no.IsSynthetic() && oldOneofs.ByName(no.Name()) == nil && sameOneofMembers(oo, no) {
+				c.add(SeveritySafe, "oneof_renamed", oo.FullName(), "oneof renamed from %q to %q", oo.Name(), no.Name())
+				break
+			}
+		}
+	}
+}
+
+func diffFieldOneof(c *changeCollector, of, nf protoreflect.FieldDescriptor) {
+	oo, no := realOneof(of), realOneof(nf)
+	switch {
+	case oo == nil && no == nil:
+	case oo == nil:
+		c.add(SeverityWireBreaking, "field_oneof_changed", of.FullName(), "field %q was moved into oneof %q", of.Name(), no.Name())
+	case no == nil:
+		c.add(SeverityWireBreaking, "field_oneof_changed", of.FullName(), "field %q was moved out of oneof %q", of.Name(), oo.Name())
+	case oo.Name() != no.Name() && !sameOneofMembers(oo, no):
+		c.add(SeverityWireBreaking, "field_oneof_changed", of.FullName(),
+			"field %q was moved from oneof %q to oneof %q", of.Name(), oo.Name(), no.Name())
+	}
+}
+
+func diffField(c *changeCollector, of, nf protoreflect.FieldDescriptor) {
+	if of.Name() != nf.Name() {
+		c.add(SeveritySafe, "field_renamed", of.FullName(), "field %d renamed from %q to %q", of.Number(), of.Name(), nf.Name())
+	}
+	if of.JSONName() != nf.JSONName() {
+		c.add(SeverityJSONBreaking, "field_json_name_changed", of.FullName(),
+			"JSON name changed from %q to %q", of.JSONName(), nf.JSONName())
+	}
+	diffFieldOneof(c, of, nf)
+
+	if of.IsList() != nf.IsList() || of.IsMap() != nf.IsMap() {
+		c.add(SeverityWireBreaking, "field_label_changed", of.FullName(),
+			"field changed from %s to %s", fieldTypeName(of), fieldTypeName(nf))
+		return
+	}
+	if of.Cardinality() != nf.Cardinality() {
+		severity := SeveritySafe
+		if nf.Cardinality() == protoreflect.Required {
+			severity = SeverityWireBreaking
+		}
+		c.add(severity, "field_label_changed", of.FullName(),
+			"label changed from %s to %s", of.Cardinality(), nf.Cardinality())
+	}
+
+	if of.IsMap() {
+		diffFieldType(c, of.FullName(), "map key", of.MapKey(), nf.MapKey())
+		diffFieldType(c, of.FullName(), "map value", of.MapValue(), nf.MapValue())
+		return
+	}
+	diffFieldType(c, of.FullName(), "field", of, nf)
+}
+
+func diffFieldType(c *changeCollector, element protoreflect.FullName, what string, of, nf protoreflect.FieldDescriptor) {
+	oldType, newType := fieldTypeName(of), fieldTypeName(nf)
+	if oldType == newType {
+		return
+	}
+	severity := SeverityWireBreaking
+	if of.Kind() != nf.Kind() && wireCompatibleKinds(of.Kind(), nf.Kind()) {
+		// still decodable from the wire, but the JSON representation differs
+		severity = SeverityJSONBreaking
+	}
+	c.add(severity, "field_type_changed", element, "%s type changed from %s to %s", what, oldType, newType)
+}
+
+func fieldTypeName(fd protoreflect.FieldDescriptor) string {
+	prefix := ""
+	if fd.IsList() {
+		prefix = "repeated "
+	}
+	switch {
+	case fd.IsMap():
+		return fmt.Sprintf("map<%s, %s>", fieldTypeName(fd.MapKey()), fieldTypeName(fd.MapValue()))
+	case fd.Message() != nil:
+		return prefix + string(fd.Message().FullName())
+	case fd.Enum() != nil:
+		return prefix + string(fd.Enum().FullName())
+	default:
+		return prefix + fd.Kind().String()
+	}
+}
+
+var wireCompatibleGroups = [][]protoreflect.Kind{
+	{protoreflect.Int32Kind, protoreflect.Uint32Kind, protoreflect.Int64Kind, protoreflect.Uint64Kind, protoreflect.BoolKind, protoreflect.EnumKind},
+	{protoreflect.Sint32Kind, protoreflect.Sint64Kind},
+	{protoreflect.Fixed32Kind, protoreflect.Sfixed32Kind},
+	{protoreflect.Fixed64Kind, protoreflect.Sfixed64Kind},
+	{protoreflect.StringKind, protoreflect.BytesKind},
+}
+
+func wireCompatibleKinds(a, b protoreflect.Kind) bool {
+	for _, group := range wireCompatibleGroups {
+		hasA, hasB := false, false
+		for _, k := range group {
+			hasA = hasA || k == a
+			hasB = hasB || k == b
+		}
+		if hasA && hasB {
+			return true
+		}
+	}
+	return false
+}
+
+func diffEnum(c *changeCollector, oe, ne protoreflect.EnumDescriptor) {
+	oldValues, newValues := oe.Values(), ne.Values()
+	for i := 0; i < oldValues.Len(); i++ {
+		ov := oldValues.Get(i)
+		nv := newValues.ByNumber(ov.Number())
+		if nv == nil {
+			c.add(SeverityWireBreaking, "enum_value_removed", ov.FullName(), "enum value %q (%d) was removed", ov.Name(), ov.Number())
+			continue
+		}
+		if nv.Name() != ov.Name() {
+			c.add(SeverityJSONBreaking, "enum_value_renamed", ov.FullName(),
+				"enum value %d renamed from %q to %q", ov.Number(), ov.Name(), nv.Name())
+		}
+	}
+	for i := 0; i < newValues.Len(); i++ {
+		nv := newValues.Get(i)
+		if oldValues.ByNumber(nv.Number()) == nil {
+			c.add(SeveritySafe, "enum_value_added", nv.FullName(), "enum value %q (%d) was added", nv.Name(), nv.Number())
+		}
+	}
+}
+
+func (m Management) protoManagerAt(ctx context.Context, revision string) (ProtoManager, error) {
+	return ResolveProtoManager(ctx, m.protoManager, revision)
+}
+
+func fileDescriptorsOf(profiles []*ProtoFileProfile) []protoreflect.FileDescriptor {
+	seen := map[string]struct{}{}
+	out := []protoreflect.FileDescriptor{}
+	for _, profile := range profiles {
+		if profile == nil || profile.ProtoPackage == nil || profile.ProtoPackage.FileDescriptor == nil {
+			continue
+		}
+		for _, path := range profile.ProtoPackage.Files {
+			if _, ok := seen[path]; ok {
+				continue
+			}
+			fd, err := profile.ProtoPackage.FileDescriptor.FindFileByPath(path)
+			if err != nil {
+				logrus.Warnf("Failed to find file descriptor by path: %q: %+v", path, err)
+				continue
+			}
+			seen[path] = struct{}{}
+			out = append(out, fd)
+		}
+	}
+	return out
+}
+
+// collectFileDescriptors returns the files of the named package, or every
+// known proto file if the package name is empty. It reports false if the
+// package does not exist in the proto files of pm.
+func collectFileDescriptors(ctx context.Context, pm ProtoManager, packageName string) ([]protoreflect.FileDescriptor, bool, error) {
+	if packageName != "" {
+		profile, err := pm.GetPackage(ctx, &GetPackageRequest{PackageName: packageName})
+		if err == nil {
+			return fileDescriptorsOf(profile.ProtoFiles), true, nil
+		}
+		exists, listErr := hasPackage(ctx, pm, packageName)
+		if listErr != nil || exists {
+			return nil, false, errors.Wrapf(err, "get package: %q", packageName)
+		}
+		return []protoreflect.FileDescriptor{}, false, nil
+	}
+
+	profiles, err := listProtoFileProfiles(ctx, pm)
+	if err != nil {
+		return nil, false, err
+	}
+	return fileDescriptorsOf(profiles), true, nil
+}
+
+// hasPackage reports whether any proto file of pm declares the package.
+func hasPackage(ctx context.Context, pm ProtoManager, packageName string) (bool, error) {
+	profiles, err := listProtoFileProfiles(ctx, pm)
+	if err != nil {
+		return false, err
+	}
+	for _, fd := range fileDescriptorsOf(profiles) {
+		if string(fd.Package()) == packageName {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m Management) DiffRevisions(ctx context.Context, req *DiffRequest) (*ProtoDiff, error) {
+	fromPM, err := m.protoManagerAt(ctx, req.From)
+	if err != nil {
+		return nil, err
+	}
+	toPM, err := m.protoManagerAt(ctx, req.To)
+	if err != nil {
+		return nil, err
+	}
+	fromFiles, fromOK, err := collectFileDescriptors(ctx, fromPM, req.PackageName)
+	if err != nil {
+		return nil, err
+	}
+	toFiles, toOK, err := collectFileDescriptors(ctx, toPM, req.PackageName)
+	if err != nil {
+		return nil, err
+	}
+	if !fromOK && !toOK {
+		return nil, errors.Errorf("Could not find package %q in either revision", req.PackageName)
+	}
+	c := &changeCollector{}
+	name := protoreflect.FullName(req.PackageName)
+	switch {
+	case !toOK:
+		c.add(SeverityWireBreaking, "package_removed", name, "package %q was removed", name)
+	case !fromOK:
+		c.add(SeveritySafe, "package_added", name, "package %q was added", name)
+	}
+
+	diff := &ProtoDiff{
+		From:    req.From,
+		To:      req.To,
+		Package: req.PackageName,
+		Summary: map[ChangeSeverity]int{
+			SeverityWireBreaking: 0,
+			SeverityJSONBreaking: 0,
+			SeveritySafe:         0,
+		},
+		Changes: append(c.changes, DiffFileDescriptors(fromFiles, toFiles)...),
+	}
+	for _, c := range diff.Changes {
+		diff.Summary[c.Severity]++
+	}
+	return diff, nil
+}
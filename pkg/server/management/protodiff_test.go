@@ -0,0 +1,169 @@
+package management
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func mustFileDescriptor(t *testing.T, in string) protoreflect.FileDescriptor {
+	fdp := &descriptorpb.FileDescriptorProto{}
+	require.NoError(t, prototext.Unmarshal([]byte(in), fdp))
+	fd, err := protodesc.NewFile(fdp, nil)
+	require.NoError(t, err)
+	return fd
+}
+
+const diffBaseProto = `
+name: "demo.proto" package: "demo" syntax: "proto3"
+message_type {
+  name: "Request"
+  field { name: "user_id" number: 1 type: TYPE_INT64 label: LABEL_OPTIONAL json_name: "userId" }
+  field { name: "email" number: 2 type: TYPE_STRING label: LABEL_OPTIONAL json_name: "email" }
+  field { name: "note" number: 3 type: TYPE_STRING label: LABEL_OPTIONAL json_name: "note" }
+}
+message_type { name: "Reply" }
+enum_type {
+  name: "State"
+  value { name: "STATE_UNKNOWN" number: 0 }
+  value { name: "STATE_ACTIVE" number: 1 }
+}
+service {
+  name: "Users"
+  method { name: "Get" input_type: ".demo.Request" output_type: ".demo.Reply" }
+  method { name: "Delete" input_type: ".demo.Request" output_type: ".demo.Reply" }
+}
+`
+
+const diffChangedProto = `
+name: "demo.proto" package: "demo" syntax: "proto3"
+message_type {
+  name: "Request"
+  field { name: "user_id" number: 1 type: TYPE_UINT64 label: LABEL_OPTIONAL json_name: "userId" }
+  field { name: "mail" number: 2 type: TYPE_STRING label: LABEL_OPTIONAL json_name: "mail" }
+  field { name: "tags" number: 4 type: TYPE_STRING label: LABEL_REPEATED json_name: "tags" }
+  reserved_range { start: 3 end: 4 }
+}
+message_type { name: "Reply" }
+message_type { name: "GetReply" }
+enum_type {
+  name: "State"
+  value { name: "STATE_UNKNOWN" number: 0 }
+}
+service {
+  name: "Users"
+  method { name: "Get" input_type: ".demo.Request" output_type: ".demo.GetReply" }
+  method { name: "List" input_type: ".demo.Request" output_type: ".demo.Reply" }
+}
+`
+
+func TestDiffFileDescriptors(t *testing.T) {
+	from := mustFileDescriptor(t, diffBaseProto)
+	to := mustFileDescriptor(t, diffChangedProto)
+
+	got := map[string]ChangeSeverity{}
+	for _, c := range DiffFileDescriptors([]protoreflect.FileDescriptor{from}, []protoreflect.FileDescriptor{to}) {
+		got[c.Kind+" "+c.Element] = c.Severity
+	}
+	assert.Equal(t, map[string]ChangeSeverity{
+		"method_removed demo.Users.Delete":            SeverityWireBreaking,
+		"method_response_type_changed demo.Users.Get": SeverityWireBreaking,
+		"method_added demo.Users.List":                SeveritySafe,
+		"message_added demo.GetReply":                 SeveritySafe,
+		"field_type_changed demo.Request.user_id":     SeverityJSONBreaking,
+		"field_renamed demo.Request.email":            SeveritySafe,
+		"field_json_name_changed demo.Request.email":  SeverityJSONBreaking,
+		"field_removed demo.Request.note":             SeverityJSONBreaking,
+		"field_added demo.Request.tags":               SeveritySafe,
+		"enum_value_removed demo.STATE_ACTIVE":        SeverityWireBreaking,
+	}, got)
+}
+
+func TestDiffFileDescriptorsUnchanged(t *testing.T) {
+	fd := mustFileDescriptor(t, diffBaseProto)
+	assert.Empty(t, DiffFileDescriptors([]protoreflect.FileDescriptor{fd}, []protoreflect.FileDescriptor{fd}))
+}
+
+const diffOneofBaseProto = `
+name: "oneof.proto" package: "demo" syntax: "proto3"
+message_type {
+  name: "Lookup"
+  field { name: "user_id" number: 1 type: TYPE_INT64 label: LABEL_OPTIONAL json_name: "userId" oneof_index: 0 }
+  field { name: "email" number: 2 type: TYPE_STRING label: LABEL_OPTIONAL json_name: "email" oneof_index: 0 }
+  field { name: "name" number: 3 type: TYPE_STRING label: LABEL_OPTIONAL json_name: "name" }
+  field { name: "limit" number: 4 type: TYPE_INT32 label: LABEL_OPTIONAL json_name: "limit" oneof_index: 1 }
+  field { name: "page" number: 5 type: TYPE_INT32 label: LABEL_OPTIONAL json_name: "page" oneof_index: 2 proto3_optional: true }
+  oneof_decl { name: "key" }
+  oneof_decl { name: "paging" }
+  oneof_decl { name: "_page" }
+}
+`
+
+const diffOneofChangedProto = `
+name: "oneof.proto" package: "demo" syntax: "proto3"
+message_type {
+  name: "Lookup"
+  field { name: "user_id" number: 1 type: TYPE_INT64 label: LABEL_OPTIONAL json_name: "userId" oneof_index: 0 }
+  field { name: "name" number: 3 type: TYPE_STRING label: LABEL_OPTIONAL json_name: "name" oneof_index: 0 }
+  field { name: "email" number: 2 type: TYPE_STRING label: LABEL_OPTIONAL json_name: "email" }
+  field { name: "limit" number: 4 type: TYPE_INT32 label: LABEL_OPTIONAL json_name: "limit" oneof_index: 1 }
+  field { name: "page" number: 5 type: TYPE_INT32 label: LABEL_OPTIONAL json_name: "page" oneof_index: 2 proto3_optional: true }
+  oneof_decl { name: "key" }
+  oneof_decl { name: "size" }
+  oneof_decl { name: "_page" }
+}
+`
+
+func TestDiffFileDescriptorsOneofs(t *testing.T) {
+	from := mustFileDescriptor(t, diffOneofBaseProto)
+	to := mustFileDescriptor(t, diffOneofChangedProto)
+
+	got := map[string]ChangeSeverity{}
+	for _, c := range DiffFileDescriptors([]protoreflect.FileDescriptor{from}, []protoreflect.FileDescriptor{to}) {
+		got[c.Kind+" "+c.Element] = c.Severity
+	}
+	assert.Equal(t, map[string]ChangeSeverity{
+		"field_oneof_changed demo.Lookup.email": SeverityWireBreaking,
+		"field_oneof_changed demo.Lookup.name":  SeverityWireBreaking,
+		"oneof_renamed demo.Lookup.paging":      SeveritySafe,
+	}, got)
+}
+
+const diffOtherPackageProto = `
+name: "billing.proto" package: "billing" syntax: "proto3"
+message_type { name: "Invoice" }
+`
+
+func TestDiffRevisionsMissingPackage(t *testing.T) {
+	current := &staticProtoManager{files: []protoreflect.FileDescriptor{
+		mustFileDescriptor(t, diffChangedProto),
+		mustFileDescriptor(t, diffOtherPackageProto),
+	}}
+	current.revisions = map[string]*staticProtoManager{"v1": {files: []protoreflect.FileDescriptor{mustFileDescriptor(t, diffBaseProto)}}}
+	m := New(SetProtoManager(current))
+	ctx := context.Background()
+
+	diff, err := m.DiffRevisions(ctx, &DiffRequest{From: "v1", PackageName: "billing"})
+	require.NoError(t, err)
+	require.Len(t, diff.Changes, 2)
+	assert.Equal(t, &ProtoChange{Severity: SeveritySafe, Kind: "package_added", Element: "billing", Message: `package "billing" was added`}, diff.Changes[0])
+	assert.Equal(t, "message_added billing.Invoice", diff.Changes[1].Kind+" "+diff.Changes[1].Element)
+
+	diff, err = m.DiffRevisions(ctx, &DiffRequest{To: "v1", PackageName: "billing"})
+	require.NoError(t, err)
+	assert.Equal(t, "package_removed", diff.Changes[0].Kind)
+	assert.Equal(t, 2, diff.Summary[SeverityWireBreaking])
+
+	diff, err = m.DiffRevisions(ctx, &DiffRequest{From: "v1", PackageName: "demo"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, diff.Changes)
+
+	_, err = m.DiffRevisions(ctx, &DiffRequest{From: "v1", PackageName: "shipping"})
+	assert.EqualError(t, err, `Could not find package "shipping" in either revision`)
+}
@@ -0,0 +1,81 @@
+package management
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/realityone/berrypost/pkg/metadata"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// ProtoStore serves messages and descriptors from the proto files of a proto
+// manager, picking file and revision from the builtin metadata. It is the
+// proto store of the proxy.
+type ProtoStore struct {
+	protoManager ProtoManager
+}
+
+func NewProtoStore(in ProtoManager) *ProtoStore {
+	return &ProtoStore{protoManager: in}
+}
+
+// protoStore looks up the descriptors of the management APIs the same way the
+// proxy does.
+func (m Management) protoStore() *ProtoStore {
+	return NewProtoStore(m.protoManager)
+}
+
+func (s *ProtoStore) FindDescriptor(ctx context.Context, name string) (protoreflect.Descriptor, error) {
+	meta, _ := metadata.FromContext(ctx)
+	pm, err := ResolveProtoManager(ctx, s.protoManager, meta.ProtoRevision)
+	if err != nil {
+		return nil, err
+	}
+	return FindDescriptorByName(ctx, pm, meta.ProtoPath, protoreflect.FullName(name))
+}
+
+func (s *ProtoStore) GetMethodDescriptor(ctx context.Context, service, method string) (protoreflect.MethodDescriptor, error) {
+	d, err := s.FindDescriptor(ctx, service)
+	if err != nil {
+		return nil, err
+	}
+	sd, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, errors.Errorf("%q is not a service", service)
+	}
+	md := sd.Methods().ByName(protoreflect.Name(method))
+	if md == nil {
+		return nil, errors.Errorf("Could not find method %q in service %q", method, service)
+	}
+	return md, nil
+}
+
+func (s *ProtoStore) GetMethodMessage(ctx context.Context, service, method string) (proto.Message, proto.Message, error) {
+	md, err := s.GetMethodDescriptor(ctx, service, method)
+	if err != nil {
+		return nil, nil, err
+	}
+	return dynamicpb.NewMessage(md.Input()), dynamicpb.NewMessage(md.Output()), nil
+}
+
+func (s *ProtoStore) GetMessageDescriptor(ctx context.Context, name string) (protoreflect.MessageDescriptor, error) {
+	d, err := s.FindDescriptor(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	md, ok := d.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil, errors.Errorf("%q is not a message", name)
+	}
+	return md, nil
+}
+
+func (s *ProtoStore) GetMessage(ctx context.Context, name string) (proto.Message, error) {
+	md, err := s.GetMessageDescriptor(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return dynamicpb.NewMessage(md), nil
+}
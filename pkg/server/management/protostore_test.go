@@ -0,0 +1,52 @@
+package management
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/realityone/berrypost/pkg/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func TestProtoStore(t *testing.T) {
+	current := &staticProtoManager{files: []protoreflect.FileDescriptor{mustFileDescriptor(t, searchProto)}}
+	current.revisions = map[string]*staticProtoManager{"v1": {files: []protoreflect.FileDescriptor{mustFileDescriptor(t, diffBaseProto)}}}
+	store := NewProtoStore(current)
+	withMeta := func(meta metadata.Metadata) context.Context {
+		return context.WithValue(context.Background(), metadata.ContextKey, meta)
+	}
+	ctx := context.Background()
+
+	req, reply, err := store.GetMethodMessage(ctx, "demo.Users", "GetUser")
+	require.NoError(t, err)
+	assert.IsType(t, &dynamicpb.Message{}, req)
+	assert.Equal(t, protoreflect.FullName("demo.GetUserRequest"), proto.MessageReflect(req).Descriptor().FullName())
+	assert.Equal(t, protoreflect.FullName("demo.User"), proto.MessageReflect(reply).Descriptor().FullName())
+
+	md, err := store.GetMethodDescriptor(withMeta(metadata.Metadata{ProtoPath: "users.proto"}), "demo.Users", "ListUsers")
+	require.NoError(t, err)
+	assert.Equal(t, protoreflect.FullName("demo.Users.ListUsers"), md.FullName())
+
+	// the revision picks the proto files
+	_, err = store.GetMethodDescriptor(ctx, "demo.Users", "Delete")
+	assert.EqualError(t, err, `Could not find method "Delete" in service "demo.Users"`)
+	md, err = store.GetMethodDescriptor(withMeta(metadata.Metadata{ProtoRevision: "v1"}), "demo.Users", "Delete")
+	require.NoError(t, err)
+	assert.Equal(t, protoreflect.FullName("demo.Request"), md.Input().FullName())
+	_, err = store.GetMessage(withMeta(metadata.Metadata{ProtoRevision: "v2"}), "demo.Request")
+	assert.Error(t, err)
+
+	m, err := store.GetMessage(ctx, "demo.User")
+	require.NoError(t, err)
+	assert.Equal(t, protoreflect.FullName("demo.User"), proto.MessageReflect(m).Descriptor().FullName())
+	_, err = store.GetMessage(ctx, "demo.Users")
+	assert.EqualError(t, err, `"demo.Users" is not a message`)
+	_, err = store.GetMethodDescriptor(ctx, "demo.User", "Get")
+	assert.EqualError(t, err, `"demo.User" is not a service`)
+	_, err = store.FindDescriptor(ctx, "demo.Missing")
+	assert.Error(t, err)
+}
@@ -0,0 +1,34 @@
+package management
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/realityone/berrypost/pkg/protohelper"
+)
+
+func (m Management) messageSchema(ctx *gin.Context) {
+	md, err := m.protoStore().GetMessageDescriptor(ctx, ctx.Param("message_name"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, protohelper.JSONSchemaOf(md))
+}
+
+func (m Management) methodSchema(ctx *gin.Context) {
+	md, err := m.protoStore().GetMethodDescriptor(ctx, ctx.Param("service"), ctx.Param("method"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	switch direction := ctx.Param("direction"); direction {
+	case "request":
+		ctx.JSON(http.StatusOK, protohelper.JSONSchemaOf(md.Input()))
+	case "response":
+		ctx.JSON(http.StatusOK, protohelper.JSONSchemaOf(md.Output()))
+	default:
+		ctx.Error(errors.Errorf("Unknown schema direction: %q, expecting request or response", direction))
+	}
+}
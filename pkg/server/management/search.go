@@ -0,0 +1,404 @@
+package management
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/realityone/berrypost/pkg/metadata"
+	"github.com/realityone/berrypost/pkg/metrics"
+	"github.com/realityone/berrypost/pkg/protohelper"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	searchIndexTTL       = time.Minute
+	searchIndexCacheSize = 8
+	defaultSearchLimit   = 20
+	maxSearchLimit       = 200
+)
+
+var searchStopWords = map[string]struct{}{
+	"a": {}, "an": {}, "the": {}, "that": {}, "which": {}, "of": {}, "to": {}, "for": {},
+	"and": {}, "or": {}, "in": {}, "on": {}, "with": {}, "is": {}, "by": {},
+}
+
+var searchKindOrder = map[string]int{
+	"method":  0,
+	"service": 1,
+	"message": 2,
+	"enum":    3,
+	"field":   4,
+}
+
+type SearchHit struct {
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	File       string `json:"file"`
+	Package    string `json:"package"`
+	ImportPath string `json:"import_path"`
+	Comment    string `json:"comment,omitempty"`
+	InvokeURL  string `json:"invoke_url"`
+	Score      int    `json:"score"`
+}
+
+type SearchRequest struct {
+	Query string
+	Limit int
+}
+
+type searchEntry struct {
+	hit           SearchHit
+	fullName      string
+	shortName     string
+	nameTokens    []string
+	commentTokens []string
+}
+
+type searchIndex struct {
+	entries []*searchEntry
+	builtAt time.Time
+}
+
+// searchIndexCache keeps the indexes of the most recently searched proto
+// revisions, the least recently used one is evicted first. Indexes are
+// rebuilt once they are older than searchIndexTTL, and searches of a revision
+// being indexed wait for that build instead of starting their own.
+type searchIndexCache struct {
+	mu      sync.Mutex
+	size    int
+	lru     *list.List
+	entries map[string]*list.Element
+}
+
+type searchIndexEntry struct {
+	revision string
+	done     chan struct{}
+	idx      *searchIndex
+	err      error
+}
+
+func newSearchIndexCache(size int) *searchIndexCache {
+	if size <= 0 {
+		size = searchIndexCacheSize
+	}
+	return &searchIndexCache{
+		size:    size,
+		lru:     list.New(),
+		entries: map[string]*list.Element{},
+	}
+}
+
+// splitSearchTokens splits identifiers and prose into lower-cased words,
+// breaking on case changes, digits and punctuation.
+func splitSearchTokens(in string) []string {
+	tokens := []string{}
+	current := []rune{}
+	flush := func() {
+		if len(current) > 0 {
+			tokens = append(tokens, strings.ToLower(string(current)))
+			current = current[:0]
+		}
+	}
+	runes := []rune(in)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		if unicode.IsUpper(r) && i > 0 && len(current) > 0 {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				flush()
+			}
+		}
+		current = append(current, r)
+	}
+	flush()
+	return tokens
+}
+
+func isSubsequence(needle, haystack string) bool {
+	if needle == "" {
+		return false
+	}
+	i := 0
+	for _, r := range haystack {
+		if i < len(needle) && rune(needle[i]) == r {
+			i++
+		}
+	}
+	return i == len(needle)
+}
+
+func matchToken(term string, tokens []string, exact, prefix, stem int) int {
+	best := 0
+	for _, t := range tokens {
+		score := 0
+		switch {
+		case t == term:
+			score = exact
+		case strings.HasPrefix(t, term):
+			score = prefix
+		case len(t) >= 4 && strings.HasPrefix(term, t):
+			// crude stemming: "updates" matches "update"
+			score = stem
+		}
+		if score > best {
+			best = score
+		}
+	}
+	return best
+}
+
+func (e *searchEntry) score(query string, terms []string) int {
+	score := 0
+	compact := strings.Join(strings.Fields(query), "")
+	switch {
+	case e.shortName == compact || e.fullName == compact:
+		score += 100
+	case strings.HasPrefix(e.shortName, compact):
+		score += 50
+	case strings.HasPrefix(e.fullName, compact):
+		score += 40
+	case strings.Contains(e.fullName, compact):
+		score += 20
+	case len(compact) >= 3 && isSubsequence(compact, e.shortName):
+		score += 10
+	}
+
+	for _, term := range terms {
+		termScore := matchToken(term, e.nameTokens, 20, 12, 10)
+		if termScore == 0 && strings.Contains(e.fullName, term) {
+			termScore = 8
+		}
+		if termScore == 0 && len(term) >= 3 && isSubsequence(term, e.shortName) {
+			termScore = 3
+		}
+		if commentScore := matchToken(term, e.commentTokens, 5, 3, 3); commentScore > termScore {
+			termScore = commentScore
+		}
+		score += termScore
+	}
+	return score
+}
+
+func newSearchEntry(kind string, d protoreflect.Descriptor, importPath, invokeURL string) *searchEntry {
+	comment := protohelper.LeadingComments(d)
+	nameTokens := splitSearchTokens(string(d.Name()))
+	if fd, ok := d.(protoreflect.FieldDescriptor); ok {
+		nameTokens = append(nameTokens, splitSearchTokens(fd.JSONName())...)
+	}
+	return &searchEntry{
+		hit: SearchHit{
+			Kind:       kind,
+			Name:       string(d.FullName()),
+			File:       d.ParentFile().Path(),
+			Package:    string(d.ParentFile().Package()),
+			ImportPath: importPath,
+			Comment:    comment,
+			InvokeURL:  invokeURL,
+		},
+		fullName:      strings.ToLower(string(d.FullName())),
+		shortName:     strings.ToLower(string(d.Name())),
+		nameTokens:    nameTokens,
+		commentTokens: splitSearchTokens(comment),
+	}
+}
+
+func buildSearchIndex(ctx context.Context, pm ProtoManager, protoRevision string) (*searchIndex, error) {
+	profiles, err := listProtoFileProfiles(ctx, pm)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := &searchIndex{builtAt: time.Now()}
+	seen := map[string]struct{}{}
+	add := func(kind string, d protoreflect.Descriptor, importPath, invokeURL string) {
+		key := kind + ":" + string(d.FullName())
+		if _, ok := seen[key]; ok {
+			return
+		}
+		seen[key] = struct{}{}
+		idx.entries = append(idx.entries, newSearchEntry(kind, d, importPath, invokeURL))
+	}
+
+	var addMessages func(protoreflect.MessageDescriptors, string, string)
+	addMessages = func(messages protoreflect.MessageDescriptors, importPath, pageURL string) {
+		for i := 0; i < messages.Len(); i++ {
+			md := messages.Get(i)
+			if md.IsMapEntry() {
+				continue
+			}
+			add("message", md, importPath, pageURL)
+			fields := md.Fields()
+			for j := 0; j < fields.Len(); j++ {
+				add("field", fields.Get(j), importPath, pageURL)
+			}
+			enums := md.Enums()
+			for j := 0; j < enums.Len(); j++ {
+				add("enum", enums.Get(j), importPath, pageURL)
+			}
+			addMessages(md.Messages(), importPath, pageURL)
+		}
+	}
+
+	for _, profile := range profiles {
+		if profile.ProtoPackage == nil {
+			continue
+		}
+		importPath := profile.ProtoPackage.Meta.ImportPath
+		pageURL := invokePageURL(importPath, protoRevision)
+		for _, fd := range fileDescriptorsOf([]*ProtoFileProfile{profile}) {
+			services := fd.Services()
+			for i := 0; i < services.Len(); i++ {
+				s := services.Get(i)
+				add("service", s, importPath, pageURL)
+				methods := s.Methods()
+				for j := 0; j < methods.Len(); j++ {
+					method := methods.Get(j)
+					add("method", method, importPath, fmt.Sprintf("%s#/%s/%s", pageURL, s.FullName(), method.Name()))
+				}
+			}
+			enums := fd.Enums()
+			for i := 0; i < enums.Len(); i++ {
+				add("enum", enums.Get(i), importPath, pageURL)
+			}
+			addMessages(fd.Messages(), importPath, pageURL)
+		}
+	}
+	return idx, nil
+}
+
+// get returns the index of the revision pm is resolved on, building it
+// outside of the lock.
+func (c *searchIndexCache) get(ctx context.Context, pm ProtoManager, protoRevision string) (*searchIndex, error) {
+	c.mu.Lock()
+	if elem, ok := c.entries[protoRevision]; ok {
+		entry := elem.Value.(*searchIndexEntry)
+		select {
+		case <-entry.done:
+			if entry.err == nil && time.Since(entry.idx.builtAt) < searchIndexTTL {
+				c.lru.MoveToFront(elem)
+				c.mu.Unlock()
+				metrics.CacheHit("search_index", true)
+				return entry.idx, nil
+			}
+			c.lru.Remove(elem)
+			delete(c.entries, protoRevision)
+		default:
+			c.lru.MoveToFront(elem)
+			c.mu.Unlock()
+			metrics.CacheHit("search_index", true)
+			select {
+			case <-entry.done:
+				return entry.idx, entry.err
+			case <-ctx.Done():
+				return nil, errors.WithStack(ctx.Err())
+			}
+		}
+	}
+	metrics.CacheHit("search_index", false)
+	entry := &searchIndexEntry{revision: protoRevision, done: make(chan struct{})}
+	c.entries[protoRevision] = c.lru.PushFront(entry)
+	for c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*searchIndexEntry).revision)
+	}
+	c.mu.Unlock()
+
+	entry.idx, entry.err = buildSearchIndex(ctx, pm, protoRevision)
+	close(entry.done)
+	if entry.err != nil {
+		// the next search builds it again
+		c.mu.Lock()
+		if elem, ok := c.entries[protoRevision]; ok && elem.Value == entry {
+			c.lru.Remove(elem)
+			delete(c.entries, protoRevision)
+		}
+		c.mu.Unlock()
+	}
+	return entry.idx, entry.err
+}
+
+func (m Management) Search(ctx context.Context, req *SearchRequest) ([]*SearchHit, error) {
+	query := strings.ToLower(strings.TrimSpace(req.Query))
+	if query == "" {
+		return []*SearchHit{}, nil
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	// unknown revisions are rejected rather than indexed, they would each
+	// take a slot of the cache
+	meta, _ := metadata.FromContext(ctx)
+	pm, err := ResolveProtoManager(ctx, m.protoManager, meta.ProtoRevision)
+	if err != nil {
+		return nil, err
+	}
+	idx, err := m.searchIndexes.get(ctx, pm, meta.ProtoRevision)
+	if err != nil {
+		return nil, err
+	}
+
+	terms := []string{}
+	for _, t := range splitSearchTokens(query) {
+		if _, ok := searchStopWords[t]; ok {
+			continue
+		}
+		terms = append(terms, t)
+	}
+
+	hits := []*SearchHit{}
+	for _, e := range idx.entries {
+		score := e.score(query, terms)
+		if score <= 0 {
+			continue
+		}
+		hit := e.hit
+		hit.Score = score
+		hits = append(hits, &hit)
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		if hits[i].Kind != hits[j].Kind {
+			return searchKindOrder[hits[i].Kind] < searchKindOrder[hits[j].Kind]
+		}
+		return hits[i].Name < hits[j].Name
+	})
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits, nil
+}
+
+func (m Management) search(ctx *gin.Context) {
+	limit, _ := strconv.Atoi(ctx.Query("limit"))
+	hits, err := m.Search(ctx, &SearchRequest{
+		Query: ctx.Query("q"),
+		Limit: limit,
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, hits)
+}
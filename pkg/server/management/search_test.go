@@ -0,0 +1,201 @@
+package management
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/realityone/berrypost/pkg/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// staticProtoManager serves fixed proto files, one package per file, and the
+// revisions it is given.
+type staticProtoManager struct {
+	files     []protoreflect.FileDescriptor
+	revisions map[string]*staticProtoManager
+	// block holds ListProtoFiles until closed, if set
+	block     chan struct{}
+	listCalls int32
+}
+
+func (pm *staticProtoManager) profile(fd protoreflect.FileDescriptor) *ProtoFileProfile {
+	files := &protoregistry.Files{}
+	if err := files.RegisterFile(fd); err != nil {
+		panic(err)
+	}
+	return &ProtoFileProfile{ProtoPackage: &ProtoPackage{
+		Meta:           ProtoMeta{ImportPath: fd.Path()},
+		Files:          []string{fd.Path()},
+		FileDescriptor: files,
+	}}
+}
+
+func (pm *staticProtoManager) ListPackages(context.Context) ([]*PackageMeta, error) {
+	out := []*PackageMeta{}
+	for _, fd := range pm.files {
+		out = append(out, &PackageMeta{Meta: ProtoMeta{ImportPath: fd.Path()}, Package: string(fd.Package())})
+	}
+	return out, nil
+}
+
+func (pm *staticProtoManager) GetPackage(_ context.Context, req *GetPackageRequest) (*ProtoPackageProfile, error) {
+	for _, fd := range pm.files {
+		if string(fd.Package()) == req.PackageName {
+			return &ProtoPackageProfile{ProtoFiles: []*ProtoFileProfile{pm.profile(fd)}}, nil
+		}
+	}
+	return nil, errors.Errorf("package %q not found", req.PackageName)
+}
+
+func (pm *staticProtoManager) ListServiceAlias(context.Context) ([]*ServiceAlias, error) {
+	return []*ServiceAlias{}, nil
+}
+
+func (pm *staticProtoManager) ListProtoFiles(context.Context) ([]*ProtoFileMeta, error) {
+	atomic.AddInt32(&pm.listCalls, 1)
+	if pm.block != nil {
+		<-pm.block
+	}
+	out := []*ProtoFileMeta{}
+	for _, fd := range pm.files {
+		out = append(out, &ProtoFileMeta{Filename: fd.Path(), Meta: ProtoMeta{ImportPath: fd.Path()}})
+	}
+	return out, nil
+}
+
+func (pm *staticProtoManager) GetProtoFile(_ context.Context, req *GetProtoFileRequest) (*ProtoFileProfile, error) {
+	for _, fd := range pm.files {
+		if fd.Path() == req.ImportPath {
+			return pm.profile(fd), nil
+		}
+	}
+	return nil, errors.Errorf("proto file %q not found", req.ImportPath)
+}
+
+func (pm *staticProtoManager) ResolveRevision(_ context.Context, revision string) (ProtoManager, error) {
+	if r, ok := pm.revisions[revision]; ok {
+		return r, nil
+	}
+	return nil, errors.Errorf("unknown revision %q", revision)
+}
+
+func (pm *staticProtoManager) ListKnownReferences(context.Context) ([]*ReferenceItem, error) {
+	out := []*ReferenceItem{}
+	for name := range pm.revisions {
+		out = append(out, &ReferenceItem{Name: name})
+	}
+	return out, nil
+}
+
+const searchProto = `
+name: "users.proto" package: "demo" syntax: "proto3"
+message_type {
+  name: "User"
+  field { name: "user_id" number: 1 type: TYPE_INT64 label: LABEL_OPTIONAL json_name: "userId" }
+  field { name: "display_name" number: 2 type: TYPE_STRING label: LABEL_OPTIONAL json_name: "displayName" }
+}
+message_type { name: "GetUserRequest" }
+message_type { name: "ListUsersRequest" }
+service {
+  name: "Users"
+  method { name: "GetUser" input_type: ".demo.GetUserRequest" output_type: ".demo.User" }
+  method { name: "ListUsers" input_type: ".demo.ListUsersRequest" output_type: ".demo.User" }
+}
+`
+
+func TestSplitSearchTokens(t *testing.T) {
+	cases := map[string][]string{
+		"GetUserByID":              {"get", "user", "by", "id"},
+		"HTTPServer":               {"http", "server"},
+		"user_id":                  {"user", "id"},
+		"v2Api":                    {"v2", "api"},
+		"demo.Users/ListUsers":     {"demo", "users", "list", "users"},
+		"Returns the user, or 404": {"returns", "the", "user", "or", "404"},
+		"":                         {},
+	}
+	for in, want := range cases {
+		assert.Equal(t, want, splitSearchTokens(in), in)
+	}
+}
+
+func searchNames(t *testing.T, m *Management, ctx context.Context, query string) []string {
+	hits, err := m.Search(ctx, &SearchRequest{Query: query})
+	require.NoError(t, err)
+	out := []string{}
+	for _, h := range hits {
+		out = append(out, h.Kind+" "+h.Name)
+	}
+	return out
+}
+
+func TestSearchRanking(t *testing.T) {
+	m := New(SetProtoManager(&staticProtoManager{files: []protoreflect.FileDescriptor{mustFileDescriptor(t, searchProto)}}))
+	ctx := context.Background()
+
+	// exact names first, then methods before messages
+	assert.Equal(t, "service demo.Users", searchNames(t, m, ctx, "users")[0])
+	assert.Equal(t, []string{"method demo.Users.GetUser", "message demo.GetUserRequest"}, searchNames(t, m, ctx, "get user")[:2])
+	// prose queries drop stop words and stem
+	assert.Equal(t, "method demo.Users.ListUsers", searchNames(t, m, ctx, "list the users")[0])
+	// fields match by proto and JSON name
+	assert.Contains(t, searchNames(t, m, ctx, "displayName"), "field demo.User.display_name")
+	// abbreviations match as subsequences
+	assert.Equal(t, "method demo.Users.GetUser", searchNames(t, m, ctx, "gtusr")[0])
+	assert.Empty(t, searchNames(t, m, ctx, "invoice"))
+}
+
+func TestSearchRevisions(t *testing.T) {
+	current := &staticProtoManager{files: []protoreflect.FileDescriptor{mustFileDescriptor(t, searchProto)}}
+	current.revisions = map[string]*staticProtoManager{"v1": {files: []protoreflect.FileDescriptor{mustFileDescriptor(t, diffBaseProto)}}}
+	m := New(SetProtoManager(current))
+
+	onRevision := func(revision string) context.Context {
+		return context.WithValue(context.Background(), metadata.ContextKey, metadata.Metadata{ProtoRevision: revision})
+	}
+	assert.Equal(t, "method demo.Users.Delete", searchNames(t, m, onRevision("v1"), "delete")[0])
+	assert.Empty(t, searchNames(t, m, context.Background(), "delete"))
+
+	_, err := m.Search(onRevision("no-such-revision"), &SearchRequest{Query: "delete"})
+	assert.Error(t, err)
+	assert.Equal(t, 2, m.searchIndexes.lru.Len())
+}
+
+func TestSearchIndexCache(t *testing.T) {
+	pm := &staticProtoManager{files: []protoreflect.FileDescriptor{mustFileDescriptor(t, searchProto)}, block: make(chan struct{})}
+	cache := newSearchIndexCache(2)
+
+	// concurrent searches share one build
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			idx, err := cache.get(context.Background(), pm, "v1")
+			assert.NoError(t, err)
+			assert.NotEmpty(t, idx.entries)
+		}()
+	}
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&pm.listCalls) == 1 }, time.Second, time.Millisecond)
+	close(pm.block)
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&pm.listCalls))
+
+	// the least recently used revision is evicted
+	_, err := cache.get(context.Background(), pm, "v2")
+	require.NoError(t, err)
+	_, err = cache.get(context.Background(), pm, "v1")
+	require.NoError(t, err)
+	_, err = cache.get(context.Background(), pm, "v3")
+	require.NoError(t, err)
+	assert.Equal(t, 2, cache.lru.Len())
+	assert.Contains(t, cache.entries, "v1")
+	assert.NotContains(t, cache.entries, "v2")
+	assert.Equal(t, int32(3), atomic.LoadInt32(&pm.listCalls))
+}
@@ -2,24 +2,59 @@ package management
 
 import (
 	"github.com/realityone/berrypost/pkg/metadata"
+	"github.com/realityone/berrypost/pkg/protohelper"
 	"github.com/realityone/berrypost/pkg/server"
 )
 
 type Method struct {
-	Name           string
-	GRPCMethodName string
-	InputSchema    string
-	ServiceMethod  string
+	Name             string      `json:"name"`
+	GRPCMethodName   string      `json:"grpc_method_name"`
+	InputSchema      string      `json:"input_schema"`
+	ServiceMethod    string      `json:"service_method"`
+	InputType        string      `json:"input_type"`
+	OutputType       string      `json:"output_type"`
+	ClientStreaming  bool        `json:"client_streaming"`
+	ServerStreaming  bool        `json:"server_streaming"`
+	IdempotencyLevel string      `json:"idempotency_level"`
+	Deprecated       bool        `json:"deprecated"`
+	Comment          string      `json:"comment"`
+	Fields           []*FieldDoc `json:"fields"`
+	// Unsafe marks methods which may have side effects, they are rejected on
+	// read-only targets. ReadOnlyBlocked is set if the prefer target is one.
+	Unsafe          bool `json:"unsafe"`
+	ReadOnlyBlocked bool `json:"read_only_blocked"`
+
+	InputSchemaNotes []*protohelper.TemplateNote `json:"input_schema_notes"`
+}
+
+type FieldDoc struct {
+	Name       string `json:"name"`
+	JSONName   string `json:"json_name"`
+	Type       string `json:"type"`
+	Comment    string `json:"comment"`
+	Deprecated bool   `json:"deprecated"`
 }
 
 type Service struct {
-	Name    string
-	Methods []*Method
+	Name       string    `json:"name"`
+	FullName   string    `json:"full_name"`
+	Deprecated bool      `json:"deprecated"`
+	Comment    string    `json:"comment"`
+	Methods    []*Method `json:"methods"`
 }
 
 type MetadataItem struct {
-	Key   string
-	Value string
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type ServiceList struct {
+	ServiceIdentifier   string          `json:"service_identifier"`
+	ImportPath          string          `json:"import_path"`
+	PreferTarget        string          `json:"prefer_target"`
+	ProtoRevision       string          `json:"proto_revision"`
+	DefaultGRPCMetadata []*MetadataItem `json:"default_grpc_metadata"`
+	Services            []*Service      `json:"services"`
 }
 
 type InvokePage struct {
@@ -33,4 +68,10 @@ type InvokePage struct {
 	DefaultGRPCMetadata  []*MetadataItem
 	Metadata             metadata.Metadata
 	KnownReferences      []*ReferenceItem
+	// ReadOnly is set if the prefer target is in read-only mode.
+	ReadOnly bool
+	// CSRFToken is sent by the page with its requests.
+	CSRFToken string
+	// TraceURL links trace IDs, see SetTraceURL.
+	TraceURL string
 }
@@ -0,0 +1,126 @@
+package management
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/realityone/berrypost/pkg/protohelper"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// constraintCheckersSize bounds the input messages whose constraint checkers
+// are kept.
+const constraintCheckersSize = 256
+
+type ValidateRequest struct {
+	Service string
+	Method  string
+	Body    []byte
+	Lenient bool
+}
+
+type MethodConstraints struct {
+	Method      string                         `json:"method"`
+	InputType   string                         `json:"input_type"`
+	Constraints []*protohelper.FieldConstraint `json:"constraints"`
+}
+
+type ValidateResult struct {
+	Valid                bool                               `json:"valid"`
+	Violations           protohelper.ValidationErrors       `json:"violations"`
+	ConstraintViolations []*protohelper.ConstraintViolation `json:"constraint_violations"`
+	DroppedFields        []string                           `json:"dropped_fields"`
+}
+
+// descriptorAnyResolver resolves Any type URLs against the proto files of the
+// revision in ctx.
+type descriptorAnyResolver struct {
+	ctx        context.Context
+	management Management
+}
+
+func (r descriptorAnyResolver) Resolve(typeURL string) (proto.Message, error) {
+	name := typeURL[strings.LastIndex(typeURL, "/")+1:]
+	md, err := r.management.protoStore().GetMessageDescriptor(r.ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return dynamicpb.NewMessage(md), nil
+}
+
+func (m Management) Validate(ctx context.Context, req *ValidateRequest) (*ValidateResult, error) {
+	md, err := m.protoStore().GetMethodDescriptor(ctx, req.Service, req.Method)
+	if err != nil {
+		return nil, err
+	}
+	resolver := descriptorAnyResolver{ctx: ctx, management: m}
+	validator := &protohelper.JSONValidator{AnyResolver: resolver}
+	problems := validator.Validate(md.Input(), req.Body)
+	out := &ValidateResult{
+		Violations:           problems.Fatal(req.Lenient),
+		ConstraintViolations: []*protohelper.ConstraintViolation{},
+		DroppedFields:        []string{},
+	}
+	if req.Lenient {
+		out.DroppedFields = problems.UnknownFields()
+	}
+	if out.Violations == nil {
+		out.Violations = protohelper.ValidationErrors{}
+	}
+	if len(out.Violations) <= 0 {
+		msg := dynamicpb.NewMessage(md.Input())
+		unmarshaler := jsonpb.Unmarshaler{
+			AllowUnknownFields: req.Lenient,
+			AnyResolver:        resolver,
+		}
+		if err := unmarshaler.Unmarshal(bytes.NewReader(req.Body), msg); err != nil {
+			return nil, errors.Wrap(err, "unmarshal request message")
+		}
+		if violations := m.constraints.Get(md.Input()).Check(msg); len(violations) > 0 {
+			out.ConstraintViolations = violations
+		}
+	}
+	out.Valid = len(out.Violations) == 0 && len(out.ConstraintViolations) == 0
+	return out, nil
+}
+
+func (m Management) validate(ctx *gin.Context) {
+	body, err := ioutil.ReadAll(ctx.Request.Body)
+	if err != nil {
+		ctx.Error(errors.Wrap(err, "read request body"))
+		return
+	}
+	lenient, _ := strconv.ParseBool(ctx.Query("lenient"))
+	result, err := m.Validate(ctx, &ValidateRequest{
+		Service: ctx.Param("service"),
+		Method:  ctx.Param("method"),
+		Body:    body,
+		Lenient: lenient,
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (m Management) listConstraints(ctx *gin.Context) {
+	md, err := m.protoStore().GetMethodDescriptor(ctx, ctx.Param("service"), ctx.Param("method"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, &MethodConstraints{
+		Method:      string(md.FullName()),
+		InputType:   string(md.Input().FullName()),
+		Constraints: m.constraints.Get(md.Input()).Describe(md.Input()),
+	})
+}
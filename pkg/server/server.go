@@ -3,13 +3,17 @@ package server
 import (
 	"html/template"
 	"net/http"
-	"time"
 
 	"github.com/realityone/berrypost"
+	"github.com/realityone/berrypost/pkg/metrics"
+	"github.com/realityone/berrypost/pkg/server/auth"
 	"github.com/realityone/berrypost/pkg/server/contrib/cacheablefs"
+	"github.com/realityone/berrypost/pkg/server/contrib/cors"
+	"github.com/realityone/berrypost/pkg/server/contrib/csrf"
 
 	"github.com/gin-contrib/pprof"
 	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
@@ -18,6 +22,12 @@ type ServerConfig struct {
 	Components     []Component
 	Meta           ServerMeta
 	GinMiddlewares []gin.HandlerFunc
+	Authenticator  *auth.Authenticator
+	CORS           *cors.Config
+	CSRF           *csrf.Protector
+	Listen         ListenConfig
+
+	csrfSet bool
 }
 
 type Component interface {
@@ -44,6 +54,30 @@ func SetGinMiddlewares(in []gin.HandlerFunc) Option {
 	}
 }
 
+// SetAuthenticator requires every request except the public paths of the
+// authenticator to be authenticated.
+func SetAuthenticator(in *auth.Authenticator) Option {
+	return func(sc *ServerConfig) {
+		sc.Authenticator = in
+	}
+}
+
+// SetCORS lets tools on the allowed origins call berrypost.
+func SetCORS(in *cors.Config) Option {
+	return func(sc *ServerConfig) {
+		sc.CORS = in
+	}
+}
+
+// SetCSRF sets the protection of state changing requests against cross-site
+// request forgery, nil disables it. It is enabled by default once an
+// authenticator is set, there are no credentials to forge without.
+func SetCSRF(in *csrf.Protector) Option {
+	return func(sc *ServerConfig) {
+		sc.CSRF, sc.csrfSet = in, true
+	}
+}
+
 type ServerMeta struct {
 	Name        string
 	Description string
@@ -53,8 +87,11 @@ type ServerMeta struct {
 type Server struct {
 	*gin.Engine
 
-	components []Component
-	meta       ServerMeta
+	components    []Component
+	states        []*componentState
+	meta          ServerMeta
+	authenticator *auth.Authenticator
+	listen        ListenConfig
 }
 
 func New(opts ...Option) *Server {
@@ -65,19 +102,40 @@ func New(opts ...Option) *Server {
 			GitHubLink:  true,
 		},
 		GinMiddlewares: []gin.HandlerFunc{gin.Logger(), gin.Recovery()},
+		Listen:         DefaultListenConfig(),
 	}
 	for _, opt := range opts {
 		opt(cfg)
 	}
+	if !cfg.csrfSet && cfg.Authenticator != nil {
+		cfg.CSRF = csrf.New()
+	}
 
 	engine := gin.New()
-	pprof.Register(engine)
-	engine.Use(cfg.GinMiddlewares...)
 	server := &Server{
-		Engine:     engine,
-		components: cfg.Components,
-		meta:       cfg.Meta,
+		Engine:        engine,
+		components:    cfg.Components,
+		meta:          cfg.Meta,
+		authenticator: cfg.Authenticator,
+		listen:        cfg.Listen,
 	}
+	// probes are registered ahead of the middlewares, they are served without
+	// authentication and not logged
+	engine.GET("/api/_health", server.healthz)
+	engine.GET("/api/_ready", server.readyz)
+
+	engine.Use(cfg.GinMiddlewares...)
+	if cfg.CORS != nil {
+		engine.Use(cors.New(*cfg.CORS))
+	}
+	if cfg.CSRF != nil {
+		engine.Use(cfg.CSRF.Middleware())
+	}
+	if cfg.Authenticator != nil {
+		engine.Use(cfg.Authenticator.Middleware())
+	}
+	pprof.Register(engine)
+	engine.GET("/metrics", gin.WrapH(metrics.Handler()))
 
 	templ := template.Must(template.ParseFS(berrypost.TemplateFS, "statics/templates/*.html"))
 	engine.SetHTMLTemplate(templ)
@@ -86,17 +144,6 @@ func New(opts ...Option) *Server {
 	return server
 }
 
-func (s *Server) Serve() {
-	srv := &http.Server{
-		Handler:      s,
-		Addr:         "0.0.0.0:8000",
-		WriteTimeout: 30 * time.Second,
-		ReadTimeout:  30 * time.Second,
-	}
-	logrus.Infof("Starting server listen and serve at: %s...", srv.Addr)
-	logrus.Fatal(srv.ListenAndServe())
-}
-
 func (s *Server) Meta() ServerMeta {
 	return s.meta
 }
@@ -139,10 +186,16 @@ func (s *Server) setupRouter() {
 	s.GET("/favicon.ico", s.favicon)
 	s.GET("/api/_intro", s.intro)
 	s.StaticFS("/assets", http.FS(cacheablefs.Wrap(berrypost.DistFS)))
+	if s.authenticator != nil {
+		s.authenticator.Setup(s.Engine)
+	}
 
 	for _, c := range s.components {
+		st := newComponentState(c)
+		s.states = append(s.states, st)
 		if err := s.SetComponent(c); err != nil {
-			logrus.Error("Failed to setup component: %+v: %+v", c.Name(), err)
+			logrus.Errorf("Failed to setup component: %+v: %+v", c.Name(), err)
+			st.fail(errors.Wrap(err, "setup"))
 			continue
 		}
 	}
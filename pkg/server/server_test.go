@@ -0,0 +1,42 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/realityone/berrypost"
+	"github.com/realityone/berrypost/pkg/server/auth"
+	"github.com/realityone/berrypost/pkg/server/contrib/csrf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSRFDefault(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	crossSite := func(s *Server) int {
+		s.POST("/echo", func(ctx *gin.Context) { ctx.Status(http.StatusNoContent) })
+		req := httptest.NewRequest(http.MethodPost, "/echo", nil)
+		req.Header.Set("Origin", "https://evil.example.com")
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// there are no credentials to forge without authentication
+	assert.Equal(t, http.StatusNoContent, crossSite(New(SetGinMiddlewares(nil))))
+	assert.Equal(t, http.StatusForbidden, crossSite(New(SetGinMiddlewares(nil), SetAuthenticator(auth.New()))))
+	assert.Equal(t, http.StatusForbidden, crossSite(New(SetGinMiddlewares(nil), SetCSRF(csrf.New()))))
+	assert.NotEqual(t, http.StatusForbidden, crossSite(New(SetGinMiddlewares(nil), SetAuthenticator(auth.New()), SetCSRF(nil))))
+}
+
+// the invoke page sets the CSRF cookie, its script has to echo the token
+func TestInvokeBundleSendsCSRFToken(t *testing.T) {
+	bundle, err := berrypost.DistFS.ReadFile("statics/dist/invoke.bundle.js")
+	require.NoError(t, err)
+	assert.Contains(t, string(bundle), "'"+csrf.HeaderName+"'")
+	page, err := berrypost.TemplateFS.ReadFile("statics/templates/invoke.html")
+	require.NoError(t, err)
+	assert.Contains(t, string(page), `<meta name="csrf-token"`)
+}
@@ -0,0 +1,172 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"os"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+	grpcmetadata "google.golang.org/grpc/metadata"
+)
+
+const (
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+
+	// TraceIDHeader carries the trace ID of an invocation in the reply.
+	TraceIDHeader = "X-Berrypost-Trace-Id"
+
+	instrumentationName = "github.com/realityone/berrypost"
+)
+
+type Option func(*Config)
+
+// Config selects the exporter of the spans, no spans are exported if
+// Exporter is empty.
+type Config struct {
+	ServiceName string
+	Exporter    string
+	// OTLPEndpoint is the host:port of the OTLP/HTTP collector.
+	OTLPEndpoint string
+	OTLPInsecure bool
+	// SampleRatio is the ratio of traces started by berrypost to sample,
+	// traces propagated from the caller follow its decision.
+	SampleRatio float64
+}
+
+func SetServiceName(in string) Option {
+	return func(cfg *Config) {
+		cfg.ServiceName = in
+	}
+}
+
+func SetExporter(in string) Option {
+	return func(cfg *Config) {
+		cfg.Exporter = in
+	}
+}
+
+func SetOTLPEndpoint(endpoint string, insecure bool) Option {
+	return func(cfg *Config) {
+		cfg.OTLPEndpoint = endpoint
+		cfg.OTLPInsecure = insecure
+	}
+}
+
+func SetSampleRatio(in float64) Option {
+	return func(cfg *Config) {
+		cfg.SampleRatio = in
+	}
+}
+
+func newExporter(ctx context.Context, cfg *Config) (sdktrace.SpanExporter, error) {
+	switch cfg.Exporter {
+	case ExporterStdout:
+		return stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterOTLP:
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.OTLPEndpoint)}
+		if cfg.OTLPInsecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(ctx, opts...)
+	default:
+		return nil, errors.Errorf("unknown trace exporter %q", cfg.Exporter)
+	}
+}
+
+// Setup installs the global tracer provider and the W3C trace context
+// propagator. The returned function flushes and stops the exporter.
+func Setup(ctx context.Context, opts ...Option) (func(context.Context) error, error) {
+	cfg := &Config{
+		ServiceName:  "berrypost",
+		OTLPEndpoint: "localhost:4318",
+		OTLPInsecure: true,
+		SampleRatio:  1,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if cfg.Exporter == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := newExporter(ctx, cfg)
+	if err != nil {
+		return nil, errors.Wrapf(err, "create %s trace exporter", cfg.Exporter)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(cfg.ServiceName))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the tracer of berrypost from the global provider.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Extract returns ctx with the trace context of the incoming HTTP headers.
+func Extract(ctx context.Context, header http.Header) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(header))
+}
+
+type metadataCarrier grpcmetadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	if vs := grpcmetadata.MD(c).Get(key); len(vs) > 0 {
+		return vs[0]
+	}
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	grpcmetadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	out := make([]string, 0, len(c))
+	for k := range c {
+		out = append(out, k)
+	}
+	return out
+}
+
+// Inject returns ctx with the trace context of its span added to the
+// outgoing gRPC metadata, so that the backend joins the trace.
+func Inject(ctx context.Context) context.Context {
+	md, _ := grpcmetadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	otel.GetTextMapPropagator().Inject(ctx, metadataCarrier(md))
+	return grpcmetadata.NewOutgoingContext(ctx, md)
+}
+
+// TraceID returns the ID of the sampled trace of ctx, empty if there is none.
+func TraceID(ctx context.Context) string {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() || !sc.IsSampled() {
+		return ""
+	}
+	return sc.TraceID().String()
+}
+
+// End records err on the span and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
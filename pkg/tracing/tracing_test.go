@@ -0,0 +1,45 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	grpcmetadata "google.golang.org/grpc/metadata"
+)
+
+func TestPropagation(t *testing.T) {
+	_, err := Setup(context.Background())
+	require.NoError(t, err)
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	// the caller of berrypost started the trace
+	header := http.Header{}
+	header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx, span := Tracer().Start(Extract(context.Background(), header), "invoke")
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", TraceID(ctx))
+
+	ctx = grpcmetadata.NewOutgoingContext(ctx, grpcmetadata.Pairs("x-user", "alice"))
+	md, _ := grpcmetadata.FromOutgoingContext(Inject(ctx))
+	assert.Equal(t, []string{"alice"}, md.Get("x-user"))
+	require.Len(t, md.Get("traceparent"), 1)
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-"+span.SpanContext().SpanID().String()+"-01", md.Get("traceparent")[0])
+	End(span, nil)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+
+	unsampled := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: span.SpanContext().TraceID(),
+		SpanID:  span.SpanContext().SpanID(),
+	}))
+	assert.Empty(t, TraceID(unsampled))
+}
@@ -24,6 +24,13 @@ var setupCodeMirror = function() {
     window.responseBodyEditor.setSize('100%', '100%');
 };
 
+var showFieldHints = function(grpcMethodName) {
+    const hintLists = document.getElementsByClassName("field-hint-list");
+    for (const l of hintLists) {
+        l.hidden = (l.dataset.grpcMethodName !== grpcMethodName);
+    }
+};
+
 var fillMethod = function() {
     const methods = document.getElementsByClassName("service-method");
     for (const m of methods) {
@@ -31,13 +38,94 @@ var fillMethod = function() {
             const methodNameInput = document.getElementById("method-name");
             methodNameInput.value = m.dataset.grpcMethodName;
             methodNameInput.dataset.serviceMethod = m.dataset.serviceMethod;
+            methodNameInput.dataset.deprecated = m.dataset.deprecated;
+            methodNameInput.dataset.unsafe = m.dataset.unsafe;
+            methodNameInput.dataset.readOnlyBlocked = m.dataset.readOnlyBlocked;
             window.requestBodyEditor.setValue(m.dataset.inputSchema);
+            showFieldHints(m.dataset.grpcMethodName);
+            showRequestWarnings(readOnlyWarnings(methodNameInput));
+        }
+    }
+};
+
+var showRequestWarnings = function(warnings) {
+    const warningBox = document.getElementById("request-warnings");
+    warningBox.innerText = warnings.join("\n");
+    warningBox.hidden = (warnings.length === 0);
+};
+
+var deprecatedFieldsOf = function(grpcMethodName) {
+    const fields = [];
+    const hintLists = document.getElementsByClassName("field-hint-list");
+    for (const l of hintLists) {
+        if (l.dataset.grpcMethodName !== grpcMethodName) {
+            continue;
+        }
+        for (const dt of l.getElementsByTagName("dt")) {
+            if (dt.dataset.deprecated === "true") {
+                fields.push(dt.dataset.fieldName);
+            }
         }
     }
+    return fields;
+};
+
+var readOnlyWarnings = function(methodNameInput) {
+    if (methodNameInput.dataset.readOnlyBlocked === "true") {
+        return [`Method ${methodNameInput.value} may have side effects, the target is read-only.`];
+    }
+    if (methodNameInput.dataset.unsafe === "true") {
+        return [`Method ${methodNameInput.value} may have side effects, it is blocked on read-only targets.`];
+    }
+    return [];
+};
+
+var collectRequestWarnings = function(methodNameInput) {
+    const warnings = readOnlyWarnings(methodNameInput);
+    if (methodNameInput.dataset.deprecated === "true") {
+        warnings.push(`Method ${methodNameInput.value} is deprecated.`);
+    }
+    var body = {};
+    try {
+        body = JSON.parse(window.requestBodyEditor.getValue());
+    } catch (e) {
+        return warnings;
+    }
+    for (const f of deprecatedFieldsOf(methodNameInput.value)) {
+        const v = body[f];
+        if (v === undefined || v === null || v === "" || v === 0 || v === false) {
+            continue;
+        }
+        if (Array.isArray(v) && v.length === 0) {
+            continue;
+        }
+        warnings.push(`Field ${f} is deprecated.`);
+    }
+    return warnings;
+};
+
+var parseWarningHeader = function(value) {
+    if (!value) {
+        return [];
+    }
+    const warnings = [];
+    const re = /299 berrypost "((?:[^"\\]|\\.)*)"/g;
+    var match;
+    while ((match = re.exec(value)) !== null) {
+        warnings.push(match[1].replace(/\\(.)/g, "$1"));
+    }
+    return warnings;
 };
 
 var clickFirstMethod = function() {
     const methods = document.getElementsByClassName("service-method");
+    const selected = decodeURIComponent(location.hash.substring(1));
+    for (const m of methods) {
+        if (m.dataset.grpcMethodName === selected) {
+            m.click();
+            return
+        }
+    }
     for (const m of methods) {
         m.click();
         return
@@ -87,6 +175,26 @@ var onReceiveResponse = function(response, serviceMethod) {
     }
 
     actionText.appendChild(actionSpan);
+    showTraceID(response.headers.get("X-Berrypost-Trace-Id"));
+};
+
+var showTraceID = function(traceID) {
+    const actionDescription = document.getElementById("request-action-description");
+    if (!traceID) {
+        return;
+    }
+    actionDescription.innerText = "Trace ";
+    const traceURL = document.querySelector('meta[name="trace-url"]').content;
+    if (!traceURL) {
+        actionDescription.appendChild(document.createTextNode(traceID));
+        return;
+    }
+    const traceLink = document.createElement("a");
+    traceLink.href = traceURL.replace("{trace_id}", encodeURIComponent(traceID));
+    traceLink.target = "_blank";
+    traceLink.rel = "noopener";
+    traceLink.innerText = traceID;
+    actionDescription.appendChild(traceLink);
 };
 
 var setupClickSend = function() {
@@ -102,6 +210,7 @@ var setupClickSend = function() {
         const headers = {
             'Content-Type': 'application/json',
             'X-Berrypost-Target': targetInput.value,
+            'X-Berrypost-Csrf-Token': document.querySelector('meta[name="csrf-token"]').content,
         };
         for (const row of Array.from(metadataTable.rows).slice(1)) {
             const inputs = row.getElementsByTagName("input");
@@ -113,7 +222,15 @@ var setupClickSend = function() {
             }
             headers[`X-Berrypost-Md-${nameInput.value}`] = valueInput.value;
         }
+        if (document.getElementById("lenient-request").checked) {
+            headers['X-Berrypost-Lenient'] = 'true';
+        }
+        if (document.getElementById("skip-constraints").checked) {
+            headers['X-Berrypost-Skip-Constraints'] = 'true';
+        }
 
+        const warnings = collectRequestWarnings(methodNameInput);
+        showRequestWarnings(warnings);
         startRequestSentAction(
             methodNameInput.dataset.serviceMethod,
             methodNameInput.value,
@@ -124,6 +241,10 @@ var setupClickSend = function() {
             headers: headers,
         }).then((response) => {
             onReceiveResponse(response, methodNameInput.dataset.serviceMethod);
+            const serverWarnings = parseWarningHeader(response.headers.get("Warning"));
+            if (serverWarnings.length > 0) {
+                showRequestWarnings(serverWarnings);
+            }
             return response.json();
         }).then((data) => {
             const prettyJSON = JSON.stringify(data, null, 2);
@@ -196,7 +317,13 @@ var GeneratePreviewCmdLine = function() {
     const body = window.requestBodyEditor.getValue();
     const targetInput = document.getElementById("target-addr");
     const metadataTable = document.getElementById("metadata-table");
-    const metadataHeaderString = metadataHeaderArgs(metadataTable);
+    var metadataHeaderString = metadataHeaderArgs(metadataTable);
+    if (document.getElementById("lenient-request").checked) {
+        metadataHeaderString += `\n    -H 'X-Berrypost-Lenient: true' \\`;
+    }
+    if (document.getElementById("skip-constraints").checked) {
+        metadataHeaderString += `\n    -H 'X-Berrypost-Skip-Constraints: true' \\`;
+    }
 
     const curlCmdLine = `## ${methodNameInput.value}
 curl -X "POST" "${baseURL}${path}" \\
@@ -210,6 +337,10 @@ var setupPreviewTrigger = function() {
     window.requestBodyEditor.on('change', (instance, changeObj) => GeneratePreviewCmdLine());
     const targetInput = document.getElementById("target-addr");
     targetInput.addEventListener('keyup', () => GeneratePreviewCmdLine());
+    const lenientInput = document.getElementById("lenient-request");
+    lenientInput.addEventListener('change', () => GeneratePreviewCmdLine());
+    const skipConstraintsInput = document.getElementById("skip-constraints");
+    skipConstraintsInput.addEventListener('change', () => GeneratePreviewCmdLine());
 };
 
 var setupRequestEditor = function() {